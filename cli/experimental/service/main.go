@@ -1,23 +1,71 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
 
+	slsaverifierv1 "github.com/slsa-framework/slsa-verifier/v2/experimental/proto/slsaverifier/v1"
 	"github.com/slsa-framework/slsa-verifier/v2/experimental/rest"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
 )
 
 func main() {
+	cacheTTL := flag.Duration("cache-ttl", 0, "time to cache successful verification results for (0 disables the cache)")
+	cacheSize := flag.Int("cache-size", 1000, "maximum number of cached verification results")
+	trustedRoot := flag.String("trusted-root", "", "path to a trusted_root.json file to use instead of fetching the trusted root from TUF")
+	trustedRootRetry := flag.Duration("trusted-root-retry", 10*time.Second, "time to wait before retrying to load the trusted root")
+	flag.Parse()
+
+	// The service is not ready until the trusted root is loaded.
+	if *trustedRoot != "" {
+		if err := verifiers.LoadTrustedRoot(*trustedRoot); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		go loadTrustedRoot(*trustedRootRetry)
+	}
+
+	if *cacheTTL > 0 {
+		rest.SetCache(rest.NewCache(rest.NewMemoryStore(*cacheSize), *cacheTTL))
+	}
+
 	r := mux.NewRouter().StrictSlash(true)
 
+	r.Use(rest.LogRequests)
+
 	r.HandleFunc("/", HomeHandler).Methods(http.MethodGet)
+	r.HandleFunc("/healthz", rest.HealthzHandler).Methods(http.MethodGet)
+	r.HandleFunc("/readyz", rest.ReadyzHandler).Methods(http.MethodGet)
+	r.Handle("/metrics", rest.MetricsHandler()).Methods(http.MethodGet)
 	r.HandleFunc("/v1/verify", rest.VerifyHandlerV1).Methods(http.MethodPost)
+	r.HandleFunc("/v1/verify/image", rest.VerifyImageHandlerV1).Methods(http.MethodPost)
+	r.HandleFunc("/v1/verify/npm", rest.VerifyNpmHandlerV1).Methods(http.MethodPost)
+	r.HandleFunc("/v1/verify/batch", rest.VerifyBatchHandlerV1).Methods(http.MethodPost)
 	http.Handle("/", r)
 
+	// The gRPC service runs alongside the REST handlers.
+	grpcAddress := ":8001"
+	lis, err := net.Listen("tcp", grpcAddress)
+	if err != nil {
+		log.Fatal(err)
+	}
+	grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(rest.LogUnaryRequests))
+	slsaverifierv1.RegisterSLSAVerifierServer(grpcSrv, rest.NewGRPCServer())
+	go func() {
+		fmt.Printf("Starting gRPC server on %v ...\n", grpcAddress)
+		if err := grpcSrv.Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
 	address := ":8000"
 	fmt.Printf("Starting HTTP server on %v ...\n", address)
 	srv := &http.Server{
@@ -33,6 +81,19 @@ func main() {
 	}
 }
 
+// loadTrustedRoot loads the trusted root from TUF, retrying until it succeeds.
+func loadTrustedRoot(retry time.Duration) {
+	for {
+		err := verifiers.InitTrustedRoot(context.Background())
+		if err == nil {
+			log.Println("Loaded the trusted root")
+			return
+		}
+		log.Printf("Loading the trusted root: %v", err)
+		time.Sleep(retry)
+	}
+}
+
 func HomeHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 
+	"github.com/slsa-framework/slsa-verifier/v2/events"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/release-utils/version"
@@ -23,6 +25,20 @@ func envWarnings() {
 	}
 }
 
+// progressObserver prints the progress of verifications to stderr.
+// The outcome of the checks is printed by the commands themselves, and
+// the timing of Rekor requests is left to observers that collect metrics.
+func progressObserver() events.Observer {
+	logger := events.NewLogger(os.Stderr)
+	return events.ObserverFunc(func(ctx context.Context, e events.Event) {
+		switch e.(type) {
+		case events.CheckPassed, events.CheckFailed, events.RekorRequest:
+			return
+		}
+		logger.OnEvent(ctx, e)
+	})
+}
+
 func rootCmd() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "slsa-verifier",
@@ -37,6 +53,7 @@ For more information on SLSA, visit https://slsa.dev`,
 	c.AddCommand(verifyArtifactCmd())
 	c.AddCommand(verifyImageCmd())
 	c.AddCommand(verifyNpmPackageCmd())
+	c.AddCommand(verifyVSACmd())
 	// We print our own errors and usage in the check function.
 	c.SilenceErrors = true
 	return c
@@ -44,5 +61,6 @@ For more information on SLSA, visit https://slsa.dev`,
 
 func main() {
 	envWarnings()
-	check(rootCmd().Execute())
+	ctx := events.WithObserver(context.Background(), progressObserver())
+	check(rootCmd().ExecuteContext(ctx))
 }
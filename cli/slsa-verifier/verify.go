@@ -26,10 +26,13 @@ import (
 const (
 	SUCCESS = "PASSED: Verified SLSA provenance"
 	FAILURE = "FAILED: SLSA verification failed"
+
+	VSASUCCESS = "PASSED: Verified SLSA verification summary attestation"
+	VSAFAILURE = "FAILED: SLSA verification summary attestation verification failed"
 )
 
 func verifyArtifactCmd() *cobra.Command {
-	o := &verify.VerifyOptions{}
+	o := &verify.VerifyArtifactOptions{}
 
 	cmd := &cobra.Command{
 		Use: "verify-artifact [flags] artifact [artifact..]",
@@ -42,10 +45,23 @@ func verifyArtifactCmd() *cobra.Command {
 		Short: "Verifies SLSA provenance on artifact blobs given as arguments (assuming same provenance)",
 		Run: func(cmd *cobra.Command, args []string) {
 			v := verify.VerifyArtifactCommand{
-				ProvenancePath:      o.ProvenancePath,
-				SourceURI:           o.SourceURI,
-				PrintProvenance:     o.PrintProvenance,
-				BuildWorkflowInputs: o.BuildWorkflowInputs.AsMap(),
+				ProvenancePath:        o.ProvenancePath,
+				SourceURI:             o.SourceURI,
+				PrintProvenance:       o.PrintProvenance,
+				BuildWorkflowInputs:   o.BuildWorkflowInputs.AsMap(),
+				TrustedRootPath:       o.TrustedRootPath,
+				RekorURL:              o.RekorURL,
+				TlogCacheDir:          o.TlogCacheDir,
+				RequireInclusionProof: o.RequireInclusionProof,
+				MinTlogEntries:        o.MinTlogEntries,
+				CertOIDCIssuers:       o.CertOIDCIssuers,
+				CertSubjectRegexps:    o.CertSubjectRegexps,
+				OutputFormat:          o.Output,
+				PolicyPath:            o.PolicyPath,
+				VSAOutputPath:         o.VSAOutputPath,
+				VSASigningKeyPath:     o.VSASigningKeyPath,
+				Batch:                 o.Batch,
+				BatchWorkers:          o.BatchWorkers,
 			}
 			if cmd.Flags().Changed("source-branch") {
 				v.SourceBranch = &o.SourceBranch
@@ -61,9 +77,11 @@ func verifyArtifactCmd() *cobra.Command {
 			}
 
 			if _, err := v.Exec(cmd.Context(), args); err != nil {
-				fmt.Fprintf(os.Stderr, "%s: %v\n", FAILURE, err)
+				if o.Output != verify.OutputJSON {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", FAILURE, err)
+				}
 				os.Exit(1)
-			} else {
+			} else if o.Output != verify.OutputJSON {
 				fmt.Fprintf(os.Stderr, "%s\n", SUCCESS)
 			}
 		},
@@ -89,9 +107,20 @@ func verifyImageCmd() *cobra.Command {
 		Short: "Verifies SLSA provenance on a container image",
 		Run: func(cmd *cobra.Command, args []string) {
 			v := verify.VerifyImageCommand{
-				SourceURI:           o.SourceURI,
-				PrintProvenance:     o.PrintProvenance,
-				BuildWorkflowInputs: o.BuildWorkflowInputs.AsMap(),
+				SourceURI:             o.SourceURI,
+				PrintProvenance:       o.PrintProvenance,
+				BuildWorkflowInputs:   o.BuildWorkflowInputs.AsMap(),
+				TrustedRootPath:       o.TrustedRootPath,
+				RekorURL:              o.RekorURL,
+				TlogCacheDir:          o.TlogCacheDir,
+				RequireInclusionProof: o.RequireInclusionProof,
+				MinTlogEntries:        o.MinTlogEntries,
+				CertOIDCIssuers:       o.CertOIDCIssuers,
+				CertSubjectRegexps:    o.CertSubjectRegexps,
+				OutputFormat:          o.Output,
+				PolicyPath:            o.PolicyPath,
+				VSAOutputPath:         o.VSAOutputPath,
+				VSASigningKeyPath:     o.VSASigningKeyPath,
 			}
 			if cmd.Flags().Changed("provenance-path") {
 				v.ProvenancePath = &o.ProvenancePath
@@ -110,9 +139,11 @@ func verifyImageCmd() *cobra.Command {
 			}
 
 			if _, err := v.Exec(cmd.Context(), args); err != nil {
-				fmt.Fprintf(os.Stderr, "%s: %v\n", FAILURE, err)
+				if o.Output != verify.OutputJSON {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", FAILURE, err)
+				}
 				os.Exit(1)
-			} else {
+			} else if o.Output != verify.OutputJSON {
 				fmt.Fprintf(os.Stderr, "%s\n", SUCCESS)
 			}
 		},
@@ -136,9 +167,18 @@ func verifyNpmPackageCmd() *cobra.Command {
 		Short: "Verifies SLSA provenance for an npm package tarball [experimental]",
 		Run: func(cmd *cobra.Command, args []string) {
 			v := verify.VerifyNpmPackageCommand{
-				SourceURI:           o.SourceURI,
-				PrintProvenance:     o.PrintProvenance,
-				BuildWorkflowInputs: o.BuildWorkflowInputs.AsMap(),
+				SourceURI:             o.SourceURI,
+				PrintProvenance:       o.PrintProvenance,
+				BuildWorkflowInputs:   o.BuildWorkflowInputs.AsMap(),
+				TrustedRootPath:       o.TrustedRootPath,
+				NpmRegistryKeysPath:   o.NpmRegistryKeysPath,
+				RekorURL:              o.RekorURL,
+				TlogCacheDir:          o.TlogCacheDir,
+				RequireInclusionProof: o.RequireInclusionProof,
+				MinTlogEntries:        o.MinTlogEntries,
+				CertOIDCIssuers:       o.CertOIDCIssuers,
+				CertSubjectRegexps:    o.CertSubjectRegexps,
+				OutputFormat:          o.Output,
 			}
 			if cmd.Flags().Changed("attestations-path") {
 				v.AttestationsPath = o.AttestationsPath
@@ -149,30 +189,27 @@ func verifyNpmPackageCmd() *cobra.Command {
 			if cmd.Flags().Changed("package-version") {
 				v.PackageVersion = &o.PackageVersion
 			}
+			// The source options are not supported for npm packages,
+			// and the command reports them in the requested format.
 			if cmd.Flags().Changed("source-branch") {
-				fmt.Fprintf(os.Stderr, "%s: --source-branch not supported\n", FAILURE)
-				os.Exit(1)
+				v.SourceBranch = &o.SourceBranch
 			}
 			if cmd.Flags().Changed("source-tag") {
-				fmt.Fprintf(os.Stderr, "%s: --source-tag not supported\n", FAILURE)
-				os.Exit(1)
+				v.SourceTag = &o.SourceTag
 			}
 			if cmd.Flags().Changed("source-versioned-tag") {
-				fmt.Fprintf(os.Stderr, "%s: --source-versioned-tag not supported\n", FAILURE)
-				os.Exit(1)
-			}
-			if cmd.Flags().Changed("print-provenance") {
-				fmt.Fprintf(os.Stderr, "%s: --print-provenance not supported\n", FAILURE)
-				os.Exit(1)
+				v.SourceVersionTag = &o.SourceVersionTag
 			}
 			if cmd.Flags().Changed("builder-id") {
 				v.BuilderID = &o.BuilderID
 			}
 
 			if _, err := v.Exec(cmd.Context(), args); err != nil {
-				fmt.Fprintf(os.Stderr, "%s: %v\n", FAILURE, err)
+				if o.Output != verify.OutputJSON {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", FAILURE, err)
+				}
 				os.Exit(1)
-			} else {
+			} else if o.Output != verify.OutputJSON {
 				fmt.Fprintf(os.Stderr, "%s\n", SUCCESS)
 			}
 		},
@@ -181,3 +218,45 @@ func verifyNpmPackageCmd() *cobra.Command {
 	o.AddFlags(cmd)
 	return cmd
 }
+
+func verifyVSACmd() *cobra.Command {
+	o := &verify.VerifyVSAOptions{}
+
+	cmd := &cobra.Command{
+		Use: "verify-vsa [flags] artifact [artifact..]",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("expects at least one artifact")
+			}
+			return nil
+		},
+		Short: "Verifies SLSA verification summary attestations (VSAs) on artifact blobs given as arguments",
+		Run: func(cmd *cobra.Command, args []string) {
+			v := verify.VerifyVSACommand{
+				VSAPath:           o.VSAPath,
+				VerifierKeyPath:   o.VerifierKeyPath,
+				MinimumBuildLevel: o.MinimumBuildLevel,
+				PrintVSA:          o.PrintVSA,
+				OutputFormat:      o.Output,
+			}
+			if cmd.Flags().Changed("resource-uri") {
+				v.ResourceURI = &o.ResourceURI
+			}
+			if cmd.Flags().Changed("verifier-id") {
+				v.VerifierID = &o.VerifierID
+			}
+
+			if err := v.Exec(cmd.Context(), args); err != nil {
+				if o.Output != verify.OutputJSON {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", VSAFAILURE, err)
+				}
+				os.Exit(1)
+			} else if o.Output != verify.OutputJSON {
+				fmt.Fprintf(os.Stderr, "%s\n", VSASUCCESS)
+			}
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
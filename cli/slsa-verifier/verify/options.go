@@ -37,9 +37,22 @@ type VerifyOptions struct {
 	/* Builder Requirements */
 	BuildWorkflowInputs workflowInputs
 	BuilderID           string
+	/* Sigstore instance */
+	RekorURL              string
+	TlogCacheDir          string
+	RequireInclusionProof bool
+	MinTlogEntries        int
+	CertOIDCIssuers       []string
+	CertSubjectRegexps    []string
 	/* Other */
 	ProvenancePath  string
 	PrintProvenance bool
+	TrustedRootPath string
+	Output          string
+	PolicyPath      string
+	/* Verification summary */
+	VSAOutputPath     string
+	VSASigningKeyPath string
 }
 
 var _ Interface = (*VerifyOptions)(nil)
@@ -70,17 +83,65 @@ func (o *VerifyOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&o.PrintProvenance, "print-provenance", false,
 		"[optional] print the verified provenance to stdout")
 
-	cmd.MarkFlagRequired("source-uri")
+	cmd.Flags().StringVar(&o.Output, "output", OutputText,
+		"[optional] output format, one of 'text' or 'json'. With 'json', a JSON document is printed to stdout for each artifact")
+
+	cmd.Flags().StringVar(&o.TrustedRootPath, "trusted-root", "",
+		"[optional] path to a Sigstore trusted_root.json file to use instead of fetching the Sigstore roots from TUF")
+
+	cmd.Flags().StringVar(&o.PolicyPath, "policy", "",
+		"[optional] path to a YAML or JSON policy file with the expected source, refs, builders and workflow inputs of each artifact")
+
+	cmd.Flags().StringVar(&o.VSAOutputPath, "vsa-output", "",
+		"[optional] path to write a signed SLSA verification summary attestation to for each verified artifact, one DSSE envelope per line")
+
+	cmd.Flags().StringVar(&o.VSASigningKeyPath, "vsa-signing-key", "",
+		"[optional] path to an unencrypted PEM private key to sign the verification summary attestations with")
+
+	addSigstoreFlags(cmd, o)
+
+	cmd.MarkFlagsRequiredTogether("vsa-output", "vsa-signing-key")
 	cmd.MarkFlagsMutuallyExclusive("source-versioned-tag", "source-tag")
+	// Either --source-uri or --policy must be supplied.
+	for _, f := range []string{
+		"source-uri", "source-branch", "source-tag", "source-versioned-tag",
+		"builder-id", "build-workflow-input",
+	} {
+		cmd.MarkFlagsMutuallyExclusive("policy", f)
+	}
+}
+
+// VerifyArtifactOptions is the top-level options for the `verifyArtifact` command.
+type VerifyArtifactOptions struct {
+	VerifyOptions
+	/* Batch */
+	Batch        bool
+	BatchWorkers int
+}
+
+var _ Interface = (*VerifyArtifactOptions)(nil)
+
+// AddFlags implements Interface.
+func (o *VerifyArtifactOptions) AddFlags(cmd *cobra.Command) {
+	o.VerifyOptions.AddFlags(cmd)
+
+	cmd.Flags().BoolVar(&o.Batch, "batch", false,
+		"[optional] verify the provenance signature once for all artifacts and print a result for each artifact")
+
+	cmd.Flags().IntVar(&o.BatchWorkers, "batch-workers", 0,
+		"[optional] maximum number of artifacts hashed in parallel in batch mode. Defaults to the number of CPUs")
+
+	cmd.MarkFlagsMutuallyExclusive("batch", "policy")
 }
 
 // VerifyNpmOptions is the top-level options for the `verifyNpmPackage` command.
 type VerifyNpmOptions struct {
 	VerifyOptions
 	/* Other */
-	AttestationsPath string
-	PackageName      string
-	PackageVersion   string
+	AttestationsPath    string
+	PackageName         string
+	PackageVersion      string
+	NpmRegistryKeysPath string
 }
 
 var _ Interface = (*VerifyNpmOptions)(nil)
@@ -116,6 +177,17 @@ func (o *VerifyNpmOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&o.PrintProvenance, "print-provenance", false,
 		"[optional] print the verified provenance to stdout")
 
+	cmd.Flags().StringVar(&o.Output, "output", OutputText,
+		"[optional] output format, one of 'text' or 'json'. With 'json', a JSON document is printed to stdout for each artifact")
+
+	cmd.Flags().StringVar(&o.TrustedRootPath, "trusted-root", "",
+		"[optional] path to a Sigstore trusted_root.json file to use instead of fetching the Sigstore roots from TUF")
+
+	cmd.Flags().StringVar(&o.NpmRegistryKeysPath, "npm-registry-keys", "",
+		"[optional] path to a file with the signing keys of the npm registry, in the format of https://registry.npmjs.org/-/npm/v1/keys. Defaults to the embedded keys")
+
+	addSigstoreFlags(cmd, &o.VerifyOptions)
+
 	cmd.MarkFlagRequired("source-uri")
 	cmd.MarkFlagRequired("builder-id")
 	cmd.MarkFlagRequired("package-name")
@@ -123,6 +195,67 @@ func (o *VerifyNpmOptions) AddFlags(cmd *cobra.Command) {
 	cmd.MarkFlagsMutuallyExclusive("source-versioned-tag", "source-tag")
 }
 
+// VerifyVSAOptions is the top-level options for the `verifyVSA` command.
+type VerifyVSAOptions struct {
+	VSAPath           string
+	VerifierKeyPath   string
+	ResourceURI       string
+	VerifierID        string
+	MinimumBuildLevel int
+	PrintVSA          bool
+	Output            string
+}
+
+var _ Interface = (*VerifyVSAOptions)(nil)
+
+// AddFlags implements Interface.
+func (o *VerifyVSAOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.VSAPath, "vsa-path", "",
+		"path to a file containing the DSSE-signed VSAs, one per line")
+
+	cmd.Flags().StringVar(&o.VerifierKeyPath, "verifier-key", "",
+		"path to the PEM public key of the trusted verifier that signed the VSAs")
+
+	cmd.Flags().StringVar(&o.ResourceURI, "resource-uri", "",
+		"[optional] expected resource URI of the artifact. Defaults to the file name of the artifact")
+
+	cmd.Flags().StringVar(&o.VerifierID, "verifier-id", "",
+		"[optional] expected ID of the verifier that emitted the VSAs")
+
+	cmd.Flags().IntVar(&o.MinimumBuildLevel, "min-build-level", 3,
+		"[optional] minimum SLSA build level that the VSA must have verified")
+
+	cmd.Flags().BoolVar(&o.PrintVSA, "print-vsa", false,
+		"[optional] print the verified VSA statement to stdout")
+
+	cmd.Flags().StringVar(&o.Output, "output", OutputText,
+		"[optional] output format, one of 'text' or 'json'. With 'json', a JSON document is printed to stdout for each artifact")
+
+	cmd.MarkFlagRequired("vsa-path")
+	cmd.MarkFlagRequired("verifier-key")
+}
+
+// addSigstoreFlags adds the flags to verify against a private Sigstore instance.
+func addSigstoreFlags(cmd *cobra.Command, o *VerifyOptions) {
+	cmd.Flags().StringVar(&o.RekorURL, "rekor-url", "",
+		"[optional] address of the Rekor transparency log. Defaults to the Sigstore public-good instance")
+
+	cmd.Flags().StringVar(&o.TlogCacheDir, "tlog-cache-dir", "",
+		"[optional] directory in which to cache the verified Rekor entries. Cached entries are verified again offline")
+
+	cmd.Flags().BoolVar(&o.RequireInclusionProof, "require-inclusion-proof", false,
+		"[optional] require the transparency log entries of Sigstore bundles to include an inclusion proof and a signed checkpoint")
+
+	cmd.Flags().IntVar(&o.MinTlogEntries, "min-tlog-entries", 1,
+		"[optional] number of transparency logs that must have a valid entry in Sigstore bundles")
+
+	cmd.Flags().StringSliceVar(&o.CertOIDCIssuers, "certificate-oidc-issuer", nil,
+		"[optional] accepted OIDC issuer of the signing certificate. Defaults to the GitHub Actions issuer")
+
+	cmd.Flags().StringSliceVar(&o.CertSubjectRegexps, "certificate-identity-regexp", nil,
+		"[optional] accepted regular expression for the identity of the signing certificate. Defaults to GitHub workflows")
+}
+
 type workflowInputs struct {
 	kv map[string]string
 }
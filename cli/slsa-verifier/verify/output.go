@@ -0,0 +1,178 @@
+// Copyright 2023 SLSA Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+// Supported output formats.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+const (
+	verdictPassed = "PASSED"
+	verdictFailed = "FAILED"
+)
+
+// validateOutputFormat returns an error if the output format is not supported.
+// An empty format is the text format.
+func validateOutputFormat(format string) error {
+	switch format {
+	case "", OutputText, OutputJSON:
+		return nil
+	default:
+		return fmt.Errorf("%w: output format '%s'", serrors.ErrorNotSupported, format)
+	}
+}
+
+type tlogEntryReport struct {
+	URL            string    `json:"url,omitempty"`
+	LogID          string    `json:"logID,omitempty"`
+	LogIndex       int64     `json:"logIndex"`
+	IntegratedTime time.Time `json:"integratedTime"`
+}
+
+// errorDetailsReport is the detail of a mismatch between the provenance
+// and the expected values.
+type errorDetailsReport struct {
+	Check    string `json:"check,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// artifactReport is the JSON document emitted for each verified artifact.
+type artifactReport struct {
+	Artifact      string              `json:"artifact"`
+	Verdict       string              `json:"verdict"`
+	Error         string              `json:"error,omitempty"`
+	ErrorCategory string              `json:"errorCategory,omitempty"`
+	ErrorDetails  *errorDetailsReport `json:"errorDetails,omitempty"`
+	BuilderID     string              `json:"builderID,omitempty"`
+	SourceURI     string              `json:"sourceURI,omitempty"`
+	SourceCommit  string              `json:"sourceCommit,omitempty"`
+	TlogEntry     *tlogEntryReport    `json:"tlogEntry,omitempty"`
+	// ProvenanceLine is set for provenance files in the JSON Lines format.
+	ProvenanceLine int             `json:"provenanceLine,omitempty"`
+	Checks         []string        `json:"checks,omitempty"`
+	Provenance     json.RawMessage `json:"provenance,omitempty"`
+}
+
+func newArtifactReport(artifact string, result *utils.VerificationResult,
+	printProvenance bool, err error,
+) *artifactReport {
+	report := &artifactReport{
+		Artifact: artifact,
+		Verdict:  verdictPassed,
+	}
+	if err != nil {
+		report.Verdict = verdictFailed
+		report.Error = err.Error()
+		report.ErrorCategory = string(serrors.CategoryOf(err))
+		var verr *serrors.VerificationError
+		if errors.As(err, &verr) {
+			report.ErrorDetails = &errorDetailsReport{
+				Check:    verr.Check,
+				Field:    verr.Field,
+				Expected: verr.Expected,
+				Actual:   verr.Actual,
+			}
+		}
+		return report
+	}
+
+	if result.BuilderID != nil {
+		report.BuilderID = result.BuilderID.String()
+	}
+	report.SourceURI = result.SourceURI
+	report.SourceCommit = result.SourceCommit
+	report.Checks = result.Checks
+	report.ProvenanceLine = result.ProvenanceLine
+	if e := result.TransparencyLogEntry; e != nil {
+		report.TlogEntry = &tlogEntryReport{
+			URL:            e.URL,
+			LogID:          e.LogID,
+			LogIndex:       e.LogIndex,
+			IntegratedTime: e.IntegratedTime.UTC(),
+		}
+	}
+	if printProvenance && json.Valid(result.Statement) {
+		report.Provenance = result.Statement
+	}
+	return report
+}
+
+// writeJSONReport writes the JSON document for an artifact on a single line.
+func writeJSONReport(w io.Writer, artifact string, result *utils.VerificationResult,
+	printProvenance bool, err error,
+) error {
+	b, merr := json.Marshal(newArtifactReport(artifact, result, printProvenance, err))
+	if merr != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrorInternal, merr)
+	}
+	_, werr := fmt.Fprintf(w, "%s\n", b)
+	return werr
+}
+
+// reportFailure reports a failure that occurs before the artifacts are
+// verified, e.g. when loading the policy. With the JSON output format, a
+// failed document is written to stdout for each artifact, so that consumers
+// get a result for each of them. Otherwise, the failure is printed to stderr
+// with the message, if any. It returns the error.
+func reportFailure(format string, artifacts []string, message string, err error) error {
+	if format == OutputJSON {
+		for _, artifact := range artifacts {
+			if werr := writeJSONReport(os.Stdout, artifact, nil, false, err); werr != nil {
+				return werr
+			}
+		}
+		return err
+	}
+	if message != "" {
+		fmt.Fprintf(os.Stderr, "%s: FAILED: %v\n\n", message, err)
+	}
+	return err
+}
+
+// writeResultTable writes a table with the verification result of each artifact.
+func writeResultTable(w io.Writer, artifacts []string, results []*utils.VerificationResult, errs []error) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ARTIFACT\tRESULT\tDETAILS")
+	for i, artifact := range artifacts {
+		if errs[i] != nil {
+			fmt.Fprintf(tw, "%s\t%s\t%v\n", artifact, verdictFailed, errs[i])
+			continue
+		}
+		details := ""
+		if results[i].BuilderID != nil {
+			details = results[i].BuilderID.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", artifact, verdictPassed, details)
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 SLSA Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/policy"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+var errNoSourceURI = errors.New("--source-uri or --policy is required")
+
+// loadPolicy loads the policy at path, if any.
+func loadPolicy(path, sourceURI string) (*policy.Policy, error) {
+	if path == "" {
+		if sourceURI == "" {
+			return nil, errNoSourceURI
+		}
+		return nil, nil
+	}
+	return policy.FromFile(path)
+}
+
+// verifyExpectations runs verify with each expectation until one succeeds,
+// and returns the expectation that succeeded.
+// It returns the first error if none succeeds.
+func verifyExpectations(expectations []policy.Expectation, digest string,
+	verify func(*options.ProvenanceOpts, *options.BuilderOpts) (*utils.VerificationResult, error),
+) (*utils.VerificationResult, *policy.Expectation, error) {
+	var errs []error
+	for i := range expectations {
+		e := &expectations[i]
+		e.ProvenanceOpts.ExpectedDigest = digest
+		result, err := verify(e.ProvenanceOpts, e.BuilderOpts)
+		if err == nil {
+			return result, e, nil
+		}
+		errs = append(errs, err)
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil, nil, fmt.Errorf("%w: no expectations", serrors.ErrorInternal)
+	case 1:
+		return nil, nil, errs[0]
+	default:
+		return nil, nil, fmt.Errorf("%w: %v", errs[0], errs[1:])
+	}
+}
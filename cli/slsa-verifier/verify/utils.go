@@ -19,6 +19,7 @@ import (
 	"hash"
 	"io"
 	"os"
+	"sync"
 )
 
 func computeFileHash(filePath string, h hash.Hash) (string, error) {
@@ -33,3 +34,32 @@ func computeFileHash(filePath string, h hash.Hash) (string, error) {
 	}
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
+
+// computeFileHashes hashes the files in parallel, using at most workers goroutines.
+// It returns the hash or the error for each file, in the same order as filePaths.
+func computeFileHashes(filePaths []string, newHash func() hash.Hash, workers int) ([]string, []error) {
+	if workers < 1 {
+		workers = 1
+	}
+	hashes := make([]string, len(filePaths))
+	errs := make([]error, len(filePaths))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(filePaths); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				hashes[i], errs[i] = computeFileHash(filePaths[i], newHash())
+			}
+		}()
+	}
+	for i := range filePaths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return hashes, errs
+}
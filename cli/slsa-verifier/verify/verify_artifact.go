@@ -19,72 +19,290 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/policy"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
 // Note: nil branch, tag, version-tag and builder-id means we ignore them during verification.
 type VerifyArtifactCommand struct {
-	ProvenancePath      string
-	BuilderID           *string
-	SourceURI           string
-	SourceBranch        *string
-	SourceTag           *string
-	SourceVersionTag    *string
-	BuildWorkflowInputs map[string]string
-	PrintProvenance     bool
+	ProvenancePath        string
+	BuilderID             *string
+	SourceURI             string
+	SourceBranch          *string
+	SourceTag             *string
+	SourceVersionTag      *string
+	BuildWorkflowInputs   map[string]string
+	PrintProvenance       bool
+	TrustedRootPath       string
+	RekorURL              string
+	TlogCacheDir          string
+	RequireInclusionProof bool
+	MinTlogEntries        int
+	CertOIDCIssuers       []string
+	CertSubjectRegexps    []string
+	OutputFormat          string
+	PolicyPath            string
+	Batch                 bool
+	BatchWorkers          int
+	VSAOutputPath         string
+	VSASigningKeyPath     string
 }
 
 func (c *VerifyArtifactCommand) Exec(ctx context.Context, artifacts []string) (*utils.TrustedBuilderID, error) {
 	var builderID *utils.TrustedBuilderID
 
-	for _, artifact := range artifacts {
-		artifactHash, err := computeFileHash(artifact, sha256.New())
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Verifying artifact %s: FAILED: %v\n\n", artifact, err)
-			return nil, err
+	if err := validateOutputFormat(c.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	pol, err := loadPolicy(c.PolicyPath, c.SourceURI)
+	if err != nil {
+		return nil, reportFailure(c.OutputFormat, artifacts,
+			fmt.Sprintf("Loading policy %s", c.PolicyPath), err)
+	}
+
+	if c.TrustedRootPath != "" {
+		if err := verifiers.LoadTrustedRoot(c.TrustedRootPath); err != nil {
+			return nil, reportFailure(c.OutputFormat, artifacts,
+				fmt.Sprintf("Loading trusted root %s", c.TrustedRootPath), err)
 		}
+	}
 
-		provenanceOpts := &options.ProvenanceOpts{
-			ExpectedSourceURI:      c.SourceURI,
-			ExpectedBranch:         c.SourceBranch,
-			ExpectedDigest:         artifactHash,
-			ExpectedVersionedTag:   c.SourceVersionTag,
-			ExpectedTag:            c.SourceTag,
-			ExpectedWorkflowInputs: c.BuildWorkflowInputs,
+	vsas, err := newVSAWriter(c.VSAOutputPath, c.VSASigningKeyPath, c.PolicyPath)
+	if err != nil {
+		return nil, reportFailure(c.OutputFormat, artifacts,
+			fmt.Sprintf("Creating VSA output %s", c.VSAOutputPath), err)
+	}
+	if vsas != nil {
+		defer vsas.Close()
+	}
+
+	if c.Batch {
+		if pol != nil {
+			return nil, reportFailure(c.OutputFormat, artifacts, "",
+				fmt.Errorf("%w: --policy in batch mode", serrors.ErrorNotSupported))
 		}
+		return c.execBatch(ctx, artifacts, vsas)
+	}
 
-		builderOpts := &options.BuilderOpts{
-			ExpectedID: c.BuilderID,
+	// With the JSON output format, every artifact is verified and reported
+	// before returning the first error.
+	var firstErr error
+	for _, artifact := range artifacts {
+		result, expectation, digest, err := c.verifyArtifact(ctx, artifact, pol)
+		if err == nil && builderID != nil && *builderID != *result.BuilderID {
+			err = fmt.Errorf("encountered different builderIDs %v %v", builderID, result.BuilderID)
+		}
+		if err == nil && vsas != nil {
+			err = vsas.write(ctx, filepath.Base(artifact), digest, result, expectation)
 		}
 
-		provenance, err := os.ReadFile(c.ProvenancePath)
+		if c.OutputFormat == OutputJSON {
+			if werr := writeJSONReport(os.Stdout, artifact, result, c.PrintProvenance, err); werr != nil {
+				return nil, werr
+			}
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Verifying artifact %s: FAILED: %v\n\n", artifact, err)
-			return nil, err
+			if c.OutputFormat != OutputJSON {
+				fmt.Fprintf(os.Stderr, "Verifying artifact %s: FAILED: %v\n\n", artifact, err)
+				return nil, err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
 
-		verifiedProvenance, outBuilderID, err := verifiers.VerifyArtifact(ctx, provenance, artifactHash, provenanceOpts, builderOpts)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Verifying artifact %s: FAILED: %v\n\n", artifact, err)
+		if c.OutputFormat != OutputJSON {
+			if c.PrintProvenance {
+				fmt.Fprintf(os.Stdout, "%s\n", string(result.Statement))
+			}
+			fmt.Fprintf(os.Stderr, "Verifying artifact %s: PASSED\n\n", artifact)
+		}
+
+		builderID = result.BuilderID
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if vsas != nil {
+		if err := vsas.commit(); err != nil {
 			return nil, err
 		}
+	}
+	return builderID, nil
+}
+
+// verifyArtifact verifies the artifact, and returns the expectation
+// it passed verification with and its digest.
+func (c *VerifyArtifactCommand) verifyArtifact(ctx context.Context, artifact string,
+	pol *policy.Policy,
+) (*utils.VerificationResult, *policy.Expectation, string, error) {
+	artifactHash, err := computeFileHash(artifact, sha256.New())
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	expectations, err := c.expectations(artifact, pol)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	verifierOpts := &options.VerifierOpts{
+		RekorURL:              c.RekorURL,
+		TlogCacheDir:          c.TlogCacheDir,
+		RequireInclusionProof: c.RequireInclusionProof,
+		MinTlogEntries:        c.MinTlogEntries,
+		CertOIDCIssuers:       c.CertOIDCIssuers,
+		CertSubjectRegexps:    c.CertSubjectRegexps,
+	}
 
-		if c.PrintProvenance {
-			fmt.Fprintf(os.Stdout, "%s\n", string(verifiedProvenance))
+	provenance, err := os.ReadFile(c.ProvenancePath)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	result, expectation, err := verifyExpectations(expectations, artifactHash,
+		func(provenanceOpts *options.ProvenanceOpts, builderOpts *options.BuilderOpts) (*utils.VerificationResult, error) {
+			return verifiers.VerifyArtifact(ctx, provenance, artifactHash, provenanceOpts, builderOpts, verifierOpts)
+		})
+	return result, expectation, artifactHash, err
+}
+
+// expectations returns the expectations for the artifact from the policy,
+// or from the command flags if there is no policy.
+func (c *VerifyArtifactCommand) expectations(artifact string, pol *policy.Policy) ([]policy.Expectation, error) {
+	if pol != nil {
+		return pol.ArtifactExpectations(artifact)
+	}
+
+	return []policy.Expectation{
+		{
+			ProvenanceOpts: &options.ProvenanceOpts{
+				ExpectedSourceURI:      c.SourceURI,
+				ExpectedBranch:         c.SourceBranch,
+				ExpectedVersionedTag:   c.SourceVersionTag,
+				ExpectedTag:            c.SourceTag,
+				ExpectedWorkflowInputs: c.BuildWorkflowInputs,
+			},
+			BuilderOpts: &options.BuilderOpts{
+				ExpectedID: c.BuilderID,
+			},
+		},
+	}, nil
+}
+
+// execBatch verifies the provenance signature once for all artifacts,
+// and reports a result for each artifact.
+func (c *VerifyArtifactCommand) execBatch(ctx context.Context, artifacts []string,
+	vsas *vsaWriter,
+) (*utils.TrustedBuilderID, error) {
+	provenance, err := os.ReadFile(c.ProvenancePath)
+	if err != nil {
+		return nil, reportFailure(c.OutputFormat, artifacts,
+			fmt.Sprintf("Reading provenance %s", c.ProvenancePath), err)
+	}
+
+	// Hash the artifacts in parallel.
+	workers := c.BatchWorkers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	hashes, hashErrs := computeFileHashes(artifacts, sha256.New, workers)
+
+	// Only verify the artifacts that could be hashed.
+	var verifiedHashes []string
+	for i := range artifacts {
+		if hashErrs[i] == nil {
+			verifiedHashes = append(verifiedHashes, hashes[i])
 		}
+	}
 
-		if builderID == nil {
-			builderID = outBuilderID
-		} else if *builderID != *outBuilderID {
-			err := fmt.Errorf("encountered different builderIDs %v %v", builderID, outBuilderID)
-			fmt.Fprintf(os.Stderr, "Verifying artifact %s: FAILED: %v\n\n", artifact, err)
+	expectations, err := c.expectations("", nil)
+	if err != nil {
+		return nil, reportFailure(c.OutputFormat, artifacts, "", err)
+	}
+	verifierOpts := &options.VerifierOpts{
+		RekorURL:              c.RekorURL,
+		TlogCacheDir:          c.TlogCacheDir,
+		RequireInclusionProof: c.RequireInclusionProof,
+		MinTlogEntries:        c.MinTlogEntries,
+		CertOIDCIssuers:       c.CertOIDCIssuers,
+		CertSubjectRegexps:    c.CertSubjectRegexps,
+	}
+
+	var batchErr error
+	var batchResults []utils.ArtifactResult
+	if len(verifiedHashes) > 0 {
+		batchResults, batchErr = verifiers.VerifyArtifacts(ctx, provenance, verifiedHashes,
+			expectations[0].ProvenanceOpts, expectations[0].BuilderOpts, verifierOpts)
+	}
+
+	// Build the result of each artifact.
+	results := make([]*utils.VerificationResult, len(artifacts))
+	errs := make([]error, len(artifacts))
+	j := 0
+	for i := range artifacts {
+		switch {
+		case hashErrs[i] != nil:
+			errs[i] = hashErrs[i]
+		case batchErr != nil:
+			errs[i] = batchErr
+		default:
+			results[i], errs[i] = batchResults[j].Result, batchResults[j].Err
+			j++
+		}
+	}
+
+	var builderID *utils.TrustedBuilderID
+	var firstErr error
+	for i, artifact := range artifacts {
+		err := errs[i]
+		if err == nil && builderID != nil && *builderID != *results[i].BuilderID {
+			err = fmt.Errorf("encountered different builderIDs %v %v", builderID, results[i].BuilderID)
+			results[i], errs[i] = nil, err
+		}
+		if err == nil && vsas != nil {
+			if err = vsas.write(ctx, filepath.Base(artifact), hashes[i], results[i], &expectations[0]); err != nil {
+				results[i], errs[i] = nil, err
+			}
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("verifying artifact %s: %w", artifact, err)
+			}
+			continue
+		}
+		builderID = results[i].BuilderID
+	}
+	if firstErr == nil && vsas != nil {
+		if err := vsas.commit(); err != nil {
 			return nil, err
 		}
-		fmt.Fprintf(os.Stderr, "Verifying artifact %s: PASSED\n\n", artifact)
 	}
 
+	if c.OutputFormat == OutputJSON {
+		for i, artifact := range artifacts {
+			if err := writeJSONReport(os.Stdout, artifact, results[i], c.PrintProvenance, errs[i]); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		writeResultTable(os.Stderr, artifacts, results, errs)
+		if c.PrintProvenance && firstErr == nil {
+			fmt.Fprintf(os.Stdout, "%s\n", string(results[0].Statement))
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
 	return builderID, nil
 }
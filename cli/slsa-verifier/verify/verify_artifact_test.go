@@ -0,0 +1,94 @@
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// captureStdout returns what f writes to stdout.
+func captureStdout(t *testing.T, f func()) []byte {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- b
+	}()
+	f()
+	w.Close()
+	return <-out
+}
+
+func Test_VerifyArtifactCommand_jsonOutput(t *testing.T) {
+	// Not parallel: the test captures stdout.
+	testdata := "../testdata/gha_container-based/v1.7.0/gha_container-based-binary-linux-amd64-v14"
+	missing := "./testdata/missing-artifact"
+
+	tests := []struct {
+		name      string
+		artifacts []string
+		verdicts  []string
+	}{
+		{
+			name:      "two failed artifacts",
+			artifacts: []string{missing, missing + "-2"},
+			verdicts:  []string{verdictFailed, verdictFailed},
+		},
+		{
+			name:      "failed artifact before verified artifact",
+			artifacts: []string{missing, testdata},
+			verdicts:  []string{verdictFailed, verdictPassed},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &VerifyArtifactCommand{
+				ProvenancePath:  testdata + ".intoto.sigstore",
+				SourceURI:       "github.com/slsa-framework/example-package",
+				TrustedRootPath: "../../../verifiers/internal/gha/testdata/trusted_root.json",
+				OutputFormat:    OutputJSON,
+			}
+
+			var err error
+			out := captureStdout(t, func() {
+				_, err = cmd.Exec(context.Background(), tt.artifacts)
+			})
+			if !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			var artifacts, verdicts []string
+			scanner := bufio.NewScanner(bytes.NewReader(out))
+			for scanner.Scan() {
+				var report artifactReport
+				if err := json.Unmarshal(scanner.Bytes(), &report); err != nil {
+					t.Fatalf("invalid document %q: %v", scanner.Text(), err)
+				}
+				artifacts = append(artifacts, report.Artifact)
+				verdicts = append(verdicts, report.Verdict)
+			}
+			if diff := cmp.Diff(tt.artifacts, artifacts); diff != "" {
+				t.Errorf("unexpected artifacts (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.verdicts, verdicts); diff != "" {
+				t.Errorf("unexpected verdicts (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
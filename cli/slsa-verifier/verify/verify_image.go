@@ -20,6 +20,7 @@ import (
 	"os"
 
 	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/policy"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils/container"
@@ -30,54 +31,138 @@ type ComputeDigestFn func(string) (string, error)
 // Note: nil branch, tag, version-tag and builder-id means we ignore them during verification.
 type VerifyImageCommand struct {
 	// May be nil if supplied alongside in the registry
-	ProvenancePath      *string
-	BuilderID           *string
-	SourceURI           string
-	SourceBranch        *string
-	SourceTag           *string
-	SourceVersionTag    *string
-	BuildWorkflowInputs map[string]string
-	PrintProvenance     bool
+	ProvenancePath        *string
+	BuilderID             *string
+	SourceURI             string
+	SourceBranch          *string
+	SourceTag             *string
+	SourceVersionTag      *string
+	BuildWorkflowInputs   map[string]string
+	PrintProvenance       bool
+	TrustedRootPath       string
+	RekorURL              string
+	TlogCacheDir          string
+	RequireInclusionProof bool
+	MinTlogEntries        int
+	CertOIDCIssuers       []string
+	CertSubjectRegexps    []string
+	OutputFormat          string
+	PolicyPath            string
+	VSAOutputPath         string
+	VSASigningKeyPath     string
 }
 
 func (c *VerifyImageCommand) Exec(ctx context.Context, artifacts []string) (*utils.TrustedBuilderID, error) {
 	artifactImage := artifacts[0]
 
+	if err := validateOutputFormat(c.OutputFormat); err != nil {
+		return nil, err
+	}
+
+	pol, err := loadPolicy(c.PolicyPath, c.SourceURI)
+	if err != nil {
+		return nil, reportFailure(c.OutputFormat, artifacts, "", err)
+	}
+
+	if c.TrustedRootPath != "" {
+		if err := verifiers.LoadTrustedRoot(c.TrustedRootPath); err != nil {
+			return nil, reportFailure(c.OutputFormat, artifacts,
+				fmt.Sprintf("Loading trusted root %s", c.TrustedRootPath), err)
+		}
+	}
+
+	vsas, err := newVSAWriter(c.VSAOutputPath, c.VSASigningKeyPath, c.PolicyPath)
+	if err != nil {
+		return nil, reportFailure(c.OutputFormat, artifacts, "", err)
+	}
+	if vsas != nil {
+		defer vsas.Close()
+	}
+
+	result, expectation, digest, err := c.verifyImage(ctx, artifactImage, pol)
+	if err == nil && vsas != nil {
+		err = vsas.write(ctx, artifactImage, digest, result, expectation)
+	}
+	if c.OutputFormat == OutputJSON {
+		if werr := writeJSONReport(os.Stdout, artifactImage, result, c.PrintProvenance, err); werr != nil {
+			return nil, werr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vsas != nil {
+		if err := vsas.commit(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.PrintProvenance && c.OutputFormat != OutputJSON {
+		fmt.Fprintf(os.Stdout, "%s\n", string(result.Statement))
+	}
+
+	return result.BuilderID, nil
+}
+
+// verifyImage verifies the image, and returns the expectation
+// it passed verification with and its digest.
+func (c *VerifyImageCommand) verifyImage(ctx context.Context, artifactImage string,
+	pol *policy.Policy,
+) (*utils.VerificationResult, *policy.Expectation, string, error) {
 	// Verify that the reference is immutable.
 	digest, err := container.GetDigestFromImmutableReference(artifactImage)
 	if err != nil {
-		return nil, err
+		return nil, nil, "", err
 	}
 
-	provenanceOpts := &options.ProvenanceOpts{
-		ExpectedSourceURI:      c.SourceURI,
-		ExpectedBranch:         c.SourceBranch,
-		ExpectedDigest:         digest,
-		ExpectedVersionedTag:   c.SourceVersionTag,
-		ExpectedTag:            c.SourceTag,
-		ExpectedWorkflowInputs: c.BuildWorkflowInputs,
+	expectations, err := c.expectations(artifactImage, pol)
+	if err != nil {
+		return nil, nil, "", err
 	}
 
-	builderOpts := &options.BuilderOpts{
-		ExpectedID: c.BuilderID,
+	verifierOpts := &options.VerifierOpts{
+		RekorURL:              c.RekorURL,
+		TlogCacheDir:          c.TlogCacheDir,
+		RequireInclusionProof: c.RequireInclusionProof,
+		MinTlogEntries:        c.MinTlogEntries,
+		CertOIDCIssuers:       c.CertOIDCIssuers,
+		CertSubjectRegexps:    c.CertSubjectRegexps,
 	}
 
 	var provenance []byte
 	if c.ProvenancePath != nil {
 		provenance, err = os.ReadFile(*c.ProvenancePath)
 		if err != nil {
-			return nil, err
+			return nil, nil, "", err
 		}
 	}
 
-	verifiedProvenance, outBuilderID, err := verifiers.VerifyImage(ctx, artifacts[0], provenance, provenanceOpts, builderOpts)
-	if err != nil {
-		return nil, err
-	}
+	result, expectation, err := verifyExpectations(expectations, digest,
+		func(provenanceOpts *options.ProvenanceOpts, builderOpts *options.BuilderOpts) (*utils.VerificationResult, error) {
+			return verifiers.VerifyImage(ctx, artifactImage, provenance, provenanceOpts, builderOpts, verifierOpts)
+		})
+	return result, expectation, digest, err
+}
 
-	if c.PrintProvenance {
-		fmt.Fprintf(os.Stdout, "%s\n", string(verifiedProvenance))
+// expectations returns the expectations for the image from the policy,
+// or from the command flags if there is no policy.
+func (c *VerifyImageCommand) expectations(image string, pol *policy.Policy) ([]policy.Expectation, error) {
+	if pol != nil {
+		return pol.ImageExpectations(image)
 	}
 
-	return outBuilderID, nil
+	return []policy.Expectation{
+		{
+			ProvenanceOpts: &options.ProvenanceOpts{
+				ExpectedSourceURI:      c.SourceURI,
+				ExpectedBranch:         c.SourceBranch,
+				ExpectedVersionedTag:   c.SourceVersionTag,
+				ExpectedTag:            c.SourceTag,
+				ExpectedWorkflowInputs: c.BuildWorkflowInputs,
+			},
+			BuilderOpts: &options.BuilderOpts{
+				ExpectedID: c.BuilderID,
+			},
+		},
+	}, nil
 }
@@ -21,76 +21,133 @@ import (
 	"fmt"
 	"os"
 
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
 type VerifyNpmPackageCommand struct {
-	AttestationsPath    string
-	BuilderID           *string
-	SourceURI           string
-	SourceBranch        *string
-	SourceTag           *string
-	SourceVersionTag    *string
-	PackageName         *string
-	PackageVersion      *string
-	BuildWorkflowInputs map[string]string
-	PrintProvenance     bool
+	AttestationsPath      string
+	BuilderID             *string
+	SourceURI             string
+	SourceBranch          *string
+	SourceTag             *string
+	SourceVersionTag      *string
+	PackageName           *string
+	PackageVersion        *string
+	BuildWorkflowInputs   map[string]string
+	PrintProvenance       bool
+	TrustedRootPath       string
+	NpmRegistryKeysPath   string
+	RekorURL              string
+	TlogCacheDir          string
+	RequireInclusionProof bool
+	MinTlogEntries        int
+	CertOIDCIssuers       []string
+	CertSubjectRegexps    []string
+	OutputFormat          string
 }
 
 func (c *VerifyNpmPackageCommand) Exec(ctx context.Context, tarballs []string) (*utils.TrustedBuilderID, error) {
 	var builderID *utils.TrustedBuilderID
-	if !options.ExperimentalEnabled() {
-		err := errors.New("feature support is only provided in SLSA_VERIFIER_EXPERIMENTAL mode")
+	if err := validateOutputFormat(c.OutputFormat); err != nil {
 		fmt.Fprintf(os.Stderr, "Verifying npm package: FAILED: %v\n\n", err)
 		return nil, err
 	}
+	if !options.ExperimentalEnabled() {
+		err := errors.New("feature support is only provided in SLSA_VERIFIER_EXPERIMENTAL mode")
+		return nil, reportFailure(c.OutputFormat, tarballs, "Verifying npm package", err)
+	}
+	if err := c.validateSourceOptions(); err != nil {
+		return nil, reportFailure(c.OutputFormat, tarballs, "Verifying npm package", err)
+	}
+	if c.TrustedRootPath != "" {
+		if err := verifiers.LoadTrustedRoot(c.TrustedRootPath); err != nil {
+			return nil, reportFailure(c.OutputFormat, tarballs,
+				fmt.Sprintf("Loading trusted root %s", c.TrustedRootPath), err)
+		}
+	}
 	for _, tarball := range tarballs {
-		tarballHash, err := computeFileHash(tarball, sha512.New())
+		result, err := c.verifyNpmPackage(ctx, tarball)
+		if c.OutputFormat == OutputJSON {
+			if werr := writeJSONReport(os.Stdout, tarball, result, c.PrintProvenance, err); werr != nil {
+				return nil, werr
+			}
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Verifying npm package %s: FAILED: %v\n\n", tarball, err)
+			if c.OutputFormat != OutputJSON {
+				fmt.Fprintf(os.Stderr, "Verifying npm package %s: FAILED: %v\n\n", tarball, err)
+			}
 			return nil, err
 		}
 
-		if c.AttestationsPath == "" {
-			fmt.Fprintf(os.Stderr, "--attestations-path is required.\n\n")
-			return nil, err
-		}
-		provenanceOpts := &options.ProvenanceOpts{
-			ExpectedSourceURI:      c.SourceURI,
-			ExpectedBranch:         c.SourceBranch,
-			ExpectedDigest:         tarballHash,
-			ExpectedVersionedTag:   c.SourceVersionTag,
-			ExpectedTag:            c.SourceTag,
-			ExpectedWorkflowInputs: c.BuildWorkflowInputs,
-			ExpectedPackageName:    c.PackageName,
-			ExpectedPackageVersion: c.PackageVersion,
+		if c.OutputFormat != OutputJSON {
+			if c.PrintProvenance {
+				fmt.Fprintf(os.Stdout, "%s\n", string(result.Statement))
+			}
+			fmt.Fprintf(os.Stderr, "Verifying npm package %s: PASSED\n\n", tarball)
 		}
 
-		builderOpts := &options.BuilderOpts{
-			ExpectedID: c.BuilderID,
-		}
+		builderID = result.BuilderID
+	}
 
-		attestations, err := os.ReadFile(c.AttestationsPath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Verifying npm package %s: FAILED: %v\n\n", tarball, err)
-			return nil, err
-		}
+	return builderID, nil
+}
 
-		verifiedProvenance, outBuilderID, err := verifiers.VerifyNpmPackage(ctx, attestations, tarballHash, provenanceOpts, builderOpts)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Verifying npm package %s: FAILED: %v\n\n", tarball, err)
-			return nil, err
-		}
+// validateSourceOptions returns an error if source options that are not
+// supported for npm packages are set.
+func (c *VerifyNpmPackageCommand) validateSourceOptions() error {
+	if c.SourceBranch != nil {
+		return fmt.Errorf("%w: --source-branch", serrors.ErrorNotSupported)
+	}
+	if c.SourceTag != nil {
+		return fmt.Errorf("%w: --source-tag", serrors.ErrorNotSupported)
+	}
+	if c.SourceVersionTag != nil {
+		return fmt.Errorf("%w: --source-versioned-tag", serrors.ErrorNotSupported)
+	}
+	return nil
+}
 
-		if c.PrintProvenance {
-			fmt.Fprintf(os.Stdout, "%s\n", string(verifiedProvenance))
-		}
+func (c *VerifyNpmPackageCommand) verifyNpmPackage(ctx context.Context, tarball string) (*utils.VerificationResult, error) {
+	tarballHash, err := computeFileHash(tarball, sha512.New())
+	if err != nil {
+		return nil, err
+	}
 
-		builderID = outBuilderID
-		fmt.Fprintf(os.Stderr, "Verifying npm package %s: PASSED\n\n", tarball)
+	if c.AttestationsPath == "" {
+		return nil, errors.New("--attestations-path is required")
+	}
+	provenanceOpts := &options.ProvenanceOpts{
+		ExpectedSourceURI:      c.SourceURI,
+		ExpectedBranch:         c.SourceBranch,
+		ExpectedDigest:         tarballHash,
+		ExpectedVersionedTag:   c.SourceVersionTag,
+		ExpectedTag:            c.SourceTag,
+		ExpectedWorkflowInputs: c.BuildWorkflowInputs,
+		ExpectedPackageName:    c.PackageName,
+		ExpectedPackageVersion: c.PackageVersion,
 	}
 
-	return builderID, nil
+	verifierOpts := &options.VerifierOpts{
+		RekorURL:              c.RekorURL,
+		TlogCacheDir:          c.TlogCacheDir,
+		NpmRegistryKeysPath:   c.NpmRegistryKeysPath,
+		RequireInclusionProof: c.RequireInclusionProof,
+		MinTlogEntries:        c.MinTlogEntries,
+		CertOIDCIssuers:       c.CertOIDCIssuers,
+		CertSubjectRegexps:    c.CertSubjectRegexps,
+	}
+
+	builderOpts := &options.BuilderOpts{
+		ExpectedID: c.BuilderID,
+	}
+
+	attestations, err := os.ReadFile(c.AttestationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifiers.VerifyNpmPackage(ctx, attestations, tarballHash, provenanceOpts, builderOpts, verifierOpts)
 }
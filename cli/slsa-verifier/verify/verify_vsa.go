@@ -0,0 +1,118 @@
+// Copyright 2023 SLSA Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+type VerifyVSACommand struct {
+	VSAPath           string
+	VerifierKeyPath   string
+	ResourceURI       *string
+	VerifierID        *string
+	MinimumBuildLevel int
+	PrintVSA          bool
+	OutputFormat      string
+}
+
+func (c *VerifyVSACommand) Exec(ctx context.Context, artifacts []string) error {
+	if err := validateOutputFormat(c.OutputFormat); err != nil {
+		return err
+	}
+	if c.ResourceURI != nil && len(artifacts) > 1 {
+		return errors.New("--resource-uri expects a single artifact")
+	}
+
+	keyPEM, err := os.ReadFile(c.VerifierKeyPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Loading verifier key %s: FAILED: %v\n\n", c.VerifierKeyPath, err)
+		return err
+	}
+	verifierKey, err := cryptoutils.UnmarshalPEMToPublicKey(keyPEM)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", serrors.ErrorInvalidKey, err)
+		fmt.Fprintf(os.Stderr, "Loading verifier key %s: FAILED: %v\n\n", c.VerifierKeyPath, err)
+		return err
+	}
+
+	attestation, err := os.ReadFile(c.VSAPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Reading VSA %s: FAILED: %v\n\n", c.VSAPath, err)
+		return err
+	}
+
+	for _, artifact := range artifacts {
+		result, err := c.verifyVSA(ctx, artifact, attestation, verifierKey)
+		if c.OutputFormat == OutputJSON {
+			if werr := writeJSONReport(os.Stdout, artifact, result, c.PrintVSA, err); werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			if c.OutputFormat != OutputJSON {
+				fmt.Fprintf(os.Stderr, "Verifying VSA for artifact %s: FAILED: %v\n\n", artifact, err)
+			}
+			return err
+		}
+
+		if c.OutputFormat != OutputJSON {
+			if c.PrintVSA {
+				fmt.Fprintf(os.Stdout, "%s\n", string(result.Statement))
+			}
+			fmt.Fprintf(os.Stderr, "Verifying VSA for artifact %s: PASSED\n\n", artifact)
+		}
+	}
+	return nil
+}
+
+func (c *VerifyVSACommand) verifyVSA(ctx context.Context, artifact string,
+	attestation []byte, verifierKey crypto.PublicKey,
+) (*utils.VerificationResult, error) {
+	artifactHash, err := computeFileHash(artifact, sha256.New())
+	if err != nil {
+		return nil, err
+	}
+
+	// By default, the resource URI is the file name of the artifact,
+	// as in the VSAs emitted by verify-artifact.
+	vsaOpts := &options.VSAOpts{
+		VerifierKey:         verifierKey,
+		ExpectedResourceURI: filepath.Base(artifact),
+		ExpectedDigest:      artifactHash,
+		MinimumBuildLevel:   c.MinimumBuildLevel,
+	}
+	if c.ResourceURI != nil {
+		vsaOpts.ExpectedResourceURI = *c.ResourceURI
+	}
+	if c.VerifierID != nil {
+		vsaOpts.ExpectedVerifierID = *c.VerifierID
+	}
+
+	return verifiers.VerifyVSA(ctx, attestation, vsaOpts)
+}
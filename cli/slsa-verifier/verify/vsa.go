@@ -0,0 +1,188 @@
+// Copyright 2023 SLSA Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	"sigs.k8s.io/release-utils/version"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/policy"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+// vsaWriter signs a VSA for each verified artifact and writes
+// them to a file, one DSSE envelope per line. The VSAs are written to a
+// temporary file, which replaces the output file only when all artifacts
+// pass verification.
+type vsaWriter struct {
+	file       *os.File
+	outputPath string
+	committed  bool
+	signingKey []byte
+	policyPath string
+	// policyDigest is the sha256 of the policy file, if any.
+	policyDigest string
+}
+
+// newVSAWriter returns a writer for the VSAs, or nil if outputPath is empty.
+// The caller must close the writer.
+func newVSAWriter(outputPath, signingKeyPath, policyPath string) (*vsaWriter, error) {
+	if outputPath == "" {
+		return nil, nil
+	}
+	if signingKeyPath == "" {
+		return nil, fmt.Errorf("%w: empty VSA signing key", serrors.ErrorInvalidKey)
+	}
+
+	signingKey, err := os.ReadFile(signingKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &vsaWriter{
+		outputPath: outputPath,
+		signingKey: signingKey,
+		policyPath: policyPath,
+	}
+	if policyPath != "" {
+		content, err := os.ReadFile(policyPath)
+		if err != nil {
+			return nil, err
+		}
+		w.policyDigest = utils.SHA256Hex(content)
+	}
+
+	// Write next to the output file so that it can be renamed.
+	w.file, err = os.CreateTemp(filepath.Dir(outputPath), "."+filepath.Base(outputPath)+".*")
+	if err != nil {
+		return nil, err
+	}
+	if err := w.file.Chmod(0o644); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// write signs and writes the VSA for an artifact that passed verification
+// with the expectation e.
+func (w *vsaWriter) write(ctx context.Context, resourceURI, digest string,
+	result *utils.VerificationResult, e *policy.Expectation,
+) error {
+	policyDescriptor, err := w.policyDescriptor(e)
+	if err != nil {
+		return err
+	}
+
+	vsa, err := utils.NewVSA(result, &utils.VSAOpts{
+		ResourceURI:     resourceURI,
+		Digest:          digest,
+		Policy:          policyDescriptor,
+		VerifierVersion: version.GetVersionInfo().GitVersion,
+		TimeVerified:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(vsa)
+	if err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrorInternal, err)
+	}
+
+	env, err := utils.SignEnvelope(ctx, intoto.PayloadType, payload, w.signingKey)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrorInternal, err)
+	}
+	_, err = fmt.Fprintf(w.file, "%s\n", b)
+	return err
+}
+
+// policyDescriptor describes the expectation the artifact was verified
+// against. The descriptor points to the policy file if there is one,
+// and otherwise its digest is that of the expectation.
+func (w *vsaWriter) policyDescriptor(e *policy.Expectation) (slsa1.ResourceDescriptor, error) {
+	annotations := map[string]interface{}{
+		"sourceURI": e.ProvenanceOpts.ExpectedSourceURI,
+	}
+	if e.ProvenanceOpts.ExpectedBranch != nil {
+		annotations["branch"] = *e.ProvenanceOpts.ExpectedBranch
+	}
+	if e.ProvenanceOpts.ExpectedTag != nil {
+		annotations["tag"] = *e.ProvenanceOpts.ExpectedTag
+	}
+	if e.ProvenanceOpts.ExpectedVersionedTag != nil {
+		annotations["versionedTag"] = *e.ProvenanceOpts.ExpectedVersionedTag
+	}
+	if len(e.ProvenanceOpts.ExpectedWorkflowInputs) > 0 {
+		annotations["workflowInputs"] = e.ProvenanceOpts.ExpectedWorkflowInputs
+	}
+	if e.BuilderOpts != nil && e.BuilderOpts.ExpectedID != nil {
+		annotations["builderID"] = *e.BuilderOpts.ExpectedID
+	}
+
+	if w.policyPath != "" {
+		return slsa1.ResourceDescriptor{
+			URI:         w.policyPath,
+			Digest:      map[string]string{"sha256": w.policyDigest},
+			Annotations: annotations,
+		}, nil
+	}
+
+	// The keys of maps are sorted, so the digest is stable.
+	b, err := json.Marshal(annotations)
+	if err != nil {
+		return slsa1.ResourceDescriptor{}, fmt.Errorf("%w: %v", serrors.ErrorInternal, err)
+	}
+	return slsa1.ResourceDescriptor{
+		Digest:      map[string]string{"sha256": utils.SHA256Hex(b)},
+		Annotations: annotations,
+	}, nil
+}
+
+// commit replaces the output file with the VSAs written so far.
+// It must be called only once all artifacts passed verification.
+func (w *vsaWriter) commit() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(w.file.Name(), w.outputPath); err != nil {
+		return err
+	}
+	w.committed = true
+	return nil
+}
+
+// Close discards the VSAs if they were not committed, leaving the output
+// file unchanged.
+func (w *vsaWriter) Close() error {
+	if w.committed {
+		return nil
+	}
+	w.file.Close()
+	return os.Remove(w.file.Name())
+}
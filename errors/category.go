@@ -0,0 +1,93 @@
+package verification
+
+import "errors"
+
+// Category is the category of a verification error, e.g. for reporting.
+type Category string
+
+// Error categories.
+const (
+	CategorySignature   Category = "signature"
+	CategoryBuilder     Category = "builder"
+	CategorySource      Category = "source"
+	CategorySubject     Category = "subject"
+	CategoryProvenance  Category = "provenance"
+	CategoryUnsupported Category = "unsupported"
+	CategoryInternal    Category = "internal"
+	CategoryOther       Category = "other"
+)
+
+var errorCategories = []struct {
+	category Category
+	errs     []error
+}{
+	{
+		category: CategorySignature,
+		errs: []error{
+			ErrorInvalidSignature, ErrorNoValidSignature,
+			ErrorInvalidCertificate, ErrorMismatchCertificate,
+			ErrorInvalidOIDCIssuer, ErrorInvalidPEM,
+			ErrorRekorSearch, ErrorNoValidRekorEntries,
+			ErrorInvalidRekorEntry, ErrorRekorPubKey,
+			ErrorInvalidTrustedRoot, ErrorInvalidKey,
+			ErrorInvalidTimestamp,
+		},
+	},
+	{
+		category: CategoryBuilder,
+		errs: []error{
+			ErrorMismatchBuilderID, ErrorInvalidBuilderID,
+			ErrorUntrustedReusableWorkflow, ErrorVerifierNotSupported,
+			ErrorMismatchVerifierID, ErrorInsufficientLevel,
+		},
+	},
+	{
+		category: CategorySource,
+		errs: []error{
+			ErrorMismatchSource, ErrorMalformedURI,
+			ErrorMismatchBranch, ErrorMismatchTag,
+			ErrorMismatchVersionedTag, ErrorInvalidRef,
+			ErrorInvalidSemver,
+		},
+	},
+	{
+		category: CategorySubject,
+		errs: []error{
+			ErrorMismatchHash, ErrorInvalidHash,
+			ErrorInvalidSubject, ErrorMutableImage,
+			ErrorImageHash, ErrorMismatchPackageName,
+			ErrorMismatchPackageVersion, ErrorInvalidPackageName,
+			ErrorMismatchResourceURI,
+		},
+	},
+	{
+		category: CategoryProvenance,
+		errs: []error{
+			ErrorInvalidDssePayload, ErrorMismatchWorkflowInputs,
+			ErrorNonVerifiableClaim, ErrorMismatchIntoto,
+			ErrorInvalidRecipe, ErrorInvalidFormat,
+			ErrorInvalidEncoding, ErrorNotPresent,
+			ErrorFailedVerification,
+		},
+	},
+	{
+		category: CategoryUnsupported,
+		errs:     []error{ErrorNotSupported},
+	},
+	{
+		category: CategoryInternal,
+		errs:     []error{ErrorInternal},
+	},
+}
+
+// CategoryOf returns the category of a verification error.
+func CategoryOf(err error) Category {
+	for _, c := range errorCategories {
+		for _, e := range c.errs {
+			if errors.Is(err, e) {
+				return c.category
+			}
+		}
+	}
+	return CategoryOther
+}
@@ -42,4 +42,13 @@ var (
 	ErrorInvalidSubject            = errors.New("invalid subject")
 	ErrorInvalidHash               = errors.New("invalid hash")
 	ErrorNotPresent                = errors.New("not present")
+	ErrorInvalidTrustedRoot        = errors.New("invalid trusted root")
+	ErrorInvalidPolicy             = errors.New("invalid policy")
+	ErrorNoPolicyRule              = errors.New("no policy rule matches")
+	ErrorInvalidKey                = errors.New("invalid key")
+	ErrorMismatchResourceURI       = errors.New("resource URI does not match VSA")
+	ErrorMismatchVerifierID        = errors.New("verifier ID does not match VSA")
+	ErrorFailedVerification        = errors.New("VSA verification result is not PASSED")
+	ErrorInsufficientLevel         = errors.New("verified SLSA level is lower than expected")
+	ErrorInvalidTimestamp          = errors.New("invalid timestamp")
 )
@@ -0,0 +1,30 @@
+package verification
+
+import "fmt"
+
+// VerificationError is returned when a value in the provenance or the signing
+// certificate does not match the expected value. It wraps one of the sentinel
+// errors, e.g. ErrorMismatchSource, so errors.Is keeps working, and exposes
+// the details of the mismatch to callers using errors.As.
+type VerificationError struct {
+	// Err is the sentinel error, e.g. ErrorMismatchSource.
+	Err error
+	// Check is the name of the failed check, e.g. "source-uri".
+	Check string
+	// Field is the provenance or certificate field that was verified,
+	// e.g. "configSource.uri".
+	Field string
+	// Expected is the expected value.
+	Expected string
+	// Actual is the value found in the provenance or certificate.
+	Actual string
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("%v: %s: expected '%s', got '%s'", e.Err, e.Field, e.Expected, e.Actual)
+}
+
+// Unwrap returns the sentinel error.
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,84 @@
+package verification
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_VerificationError(t *testing.T) {
+	t.Parallel()
+
+	verr := &VerificationError{
+		Err:      ErrorMismatchBranch,
+		Check:    "branch",
+		Field:    "branch",
+		Expected: "main",
+		Actual:   "dev",
+	}
+	err := fmt.Errorf("verifying artifact: %w", verr)
+
+	if !errors.Is(err, ErrorMismatchBranch) {
+		t.Errorf("expected %v to match %v", err, ErrorMismatchBranch)
+	}
+	if errors.Is(err, ErrorMismatchTag) {
+		t.Errorf("unexpected match of %v with %v", err, ErrorMismatchTag)
+	}
+
+	var got *VerificationError
+	if !errors.As(err, &got) {
+		t.Fatalf("expected a VerificationError in %v", err)
+	}
+	if got != verr {
+		t.Errorf("unexpected error: %v", got)
+	}
+
+	expected := "branch used to generate the binary does not match provenance: branch: expected 'main', got 'dev'"
+	if verr.Error() != expected {
+		t.Errorf("unexpected message: %s", verr.Error())
+	}
+}
+
+func Test_CategoryOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected Category
+	}{
+		{
+			name:     "signature",
+			err:      fmt.Errorf("%w: bad", ErrorInvalidSignature),
+			expected: CategorySignature,
+		},
+		{
+			name: "verification error",
+			err: &VerificationError{
+				Err:   ErrorMismatchSource,
+				Check: "source-uri",
+			},
+			expected: CategorySource,
+		},
+		{
+			name:     "unsupported",
+			err:      ErrorNotSupported,
+			expected: CategoryUnsupported,
+		},
+		{
+			name:     "other",
+			err:      errors.New("network error"),
+			expected: CategoryOther,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := CategoryOf(tt.err); got != tt.expected {
+				t.Errorf("unexpected category: %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
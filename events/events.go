@@ -0,0 +1,174 @@
+// Package events lets callers observe the progress of a verification.
+//
+// The verifiers never write to the standard output or error streams.
+// Instead, they emit typed events to the Observer attached to the context
+// passed to them with WithObserver. Events are dropped if there is none.
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event is an event emitted during a verification.
+type Event interface {
+	// String returns a human-readable description of the event.
+	String() string
+}
+
+// SignatureVerified is emitted when the signature on provenance is verified.
+type SignatureVerified struct {
+	// Signer identifies the signer, e.g. the certificate identity
+	// or the name of the key.
+	Signer string
+}
+
+func (e SignatureVerified) String() string {
+	return fmt.Sprintf("Verified signature by '%s'", e.Signer)
+}
+
+// TlogEntryUsed is emitted when a signature is verified against
+// a transparency log entry.
+type TlogEntryUsed struct {
+	LogIndex int64
+	URL      string
+}
+
+func (e TlogEntryUsed) String() string {
+	return fmt.Sprintf("Verified signature against tlog entry index %d at URL: %s", e.LogIndex, e.URL)
+}
+
+// RekorRequest is emitted after each request to the Rekor API.
+type RekorRequest struct {
+	// Operation is the name of the API operation, e.g. "SearchIndex".
+	Operation string
+	Duration  time.Duration
+	// Err is the error returned by the request, if it failed.
+	Err error
+}
+
+func (e RekorRequest) String() string {
+	if e.Err != nil {
+		return fmt.Sprintf("Rekor %s request failed after %v: %v", e.Operation, e.Duration, e.Err)
+	}
+	return fmt.Sprintf("Rekor %s request completed in %v", e.Operation, e.Duration)
+}
+
+// BuildVerified is emitted when the builder and source of provenance
+// are verified.
+type BuildVerified struct {
+	BuilderID    string
+	SourceCommit string
+}
+
+func (e BuildVerified) String() string {
+	return fmt.Sprintf("Verified build using builder %s at commit %s", e.BuilderID, e.SourceCommit)
+}
+
+// AttestationVerified is emitted when an attestation of a provenance file
+// in the JSON Lines format is verified.
+type AttestationVerified struct {
+	// Line is the 1-based line of the attestation.
+	Line int
+}
+
+func (e AttestationVerified) String() string {
+	return fmt.Sprintf("Verified provenance on line %d", e.Line)
+}
+
+// CheckPassed is emitted for each check performed by a successful verification.
+type CheckPassed struct {
+	// Check is the name of the check, e.g. utils.CheckSourceURI.
+	Check string
+}
+
+func (e CheckPassed) String() string {
+	return fmt.Sprintf("Check %s: PASSED", e.Check)
+}
+
+// CheckFailed is emitted when a verification fails.
+type CheckFailed struct {
+	// Check is the name of the failed check. It is empty if unknown.
+	Check string
+	Err   error
+}
+
+func (e CheckFailed) String() string {
+	if e.Check == "" {
+		return fmt.Sprintf("Verification FAILED: %v", e.Err)
+	}
+	return fmt.Sprintf("Check %s: FAILED: %v", e.Check, e.Err)
+}
+
+// Info is emitted for other progress of a verification.
+type Info struct {
+	Message string
+}
+
+func (e Info) String() string {
+	return e.Message
+}
+
+// Warning is emitted for unexpected conditions that do not fail
+// the verification.
+type Warning struct {
+	Message string
+}
+
+func (e Warning) String() string {
+	return "WARNING: " + e.Message
+}
+
+// Observer receives the events emitted during verifications.
+// It may be called concurrently.
+type Observer interface {
+	OnEvent(ctx context.Context, e Event)
+}
+
+// ObserverFunc is a function used as an Observer.
+type ObserverFunc func(ctx context.Context, e Event)
+
+// OnEvent implements Observer.OnEvent.
+func (f ObserverFunc) OnEvent(ctx context.Context, e Event) {
+	f(ctx, e)
+}
+
+type observerKey struct{}
+
+// WithObserver returns a context that emits events to o.
+func WithObserver(ctx context.Context, o Observer) context.Context {
+	return context.WithValue(ctx, observerKey{}, o)
+}
+
+// ObserverFromContext returns the observer attached to the context, or nil.
+func ObserverFromContext(ctx context.Context) Observer {
+	o, _ := ctx.Value(observerKey{}).(Observer)
+	return o
+}
+
+// Emit emits an event to the observer attached to the context, if any.
+func Emit(ctx context.Context, e Event) {
+	if o := ObserverFromContext(ctx); o != nil {
+		o.OnEvent(ctx, e)
+	}
+}
+
+// NewLogger returns an observer that writes the description of the
+// events to w, one per line.
+func NewLogger(w io.Writer) Observer {
+	return &logger{w: w}
+}
+
+type logger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *logger) OnEvent(_ context.Context, e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintln(l.w, e.String())
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Emit(t *testing.T) {
+	t.Parallel()
+
+	// No observer: the event is dropped.
+	Emit(context.Background(), Info{Message: "dropped"})
+
+	var got []Event
+	ctx := WithObserver(context.Background(), ObserverFunc(func(_ context.Context, e Event) {
+		got = append(got, e)
+	}))
+	Emit(ctx, SignatureVerified{Signer: "signer"})
+	Emit(ctx, CheckPassed{Check: "source-uri"})
+
+	expected := []Event{
+		SignatureVerified{Signer: "signer"},
+		CheckPassed{Check: "source-uri"},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected events (-want +got): \n%s", diff)
+	}
+}
+
+func Test_NewLogger(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	ctx := WithObserver(context.Background(), NewLogger(&b))
+	Emit(ctx, TlogEntryUsed{LogIndex: 42, URL: "https://rekor.sigstore.dev"})
+	Emit(ctx, BuildVerified{BuilderID: "https://builder", SourceCommit: "abcdef"})
+	Emit(ctx, AttestationVerified{Line: 2})
+	Emit(ctx, CheckPassed{Check: "builder-id"})
+	Emit(ctx, CheckFailed{Check: "source-uri", Err: errors.New("mismatch")})
+	Emit(ctx, CheckFailed{Err: errors.New("invalid")})
+	Emit(ctx, Warning{Message: "unexpected"})
+
+	expected := `Verified signature against tlog entry index 42 at URL: https://rekor.sigstore.dev
+Verified build using builder https://builder at commit abcdef
+Verified provenance on line 2
+Check builder-id: PASSED
+Check source-uri: FAILED: mismatch
+Verification FAILED: invalid
+WARNING: unexpected
+`
+	if diff := cmp.Diff(expected, b.String()); diff != "" {
+		t.Errorf("unexpected output (-want +got): \n%s", diff)
+	}
+}
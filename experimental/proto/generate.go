@@ -0,0 +1,9 @@
+// Package proto contains the protobuf definitions of the experimental
+// verification service.
+//
+// The code is generated by buf, which compiles the definitions itself and
+// runs the plugins at the versions pinned in buf.gen.yaml. buf does not
+// report a protoc version, hence "protoc (unknown)" in the generated files.
+package proto
+
+//go:generate go run github.com/bufbuild/buf/cmd/buf@v1.47.2 generate
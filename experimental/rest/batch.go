@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+)
+
+// maxBatchItems is the maximum number of artifacts in a batch.
+const maxBatchItems = 100
+
+type v1BatchQuery struct {
+	// The provenance, either a single one or several. Items refer to
+	// the provenance by its index.
+	DsseEnvelope  string        `json:"provenanceContent"`
+	DsseEnvelopes []string      `json:"provenanceContents"`
+	Items         []v1BatchItem `json:"items"`
+}
+
+// v1BatchItem is an artifact of a batch with its expectations.
+type v1BatchItem struct {
+	// Compulsory fields.
+	Source       string `json:"source"`
+	ArtifactHash string `json:"artifactHash"`
+	// Optional fields.
+	// Provenance is the index of the provenance of the artifact.
+	Provenance      int     `json:"provenance"`
+	BuilderID       *string `json:"builderID"`
+	Tag             *string `json:"tag"`
+	Branch          *string `json:"branch"`
+	VersionedTag    *string `json:"versionedTag"`
+	PrintProvenance *bool   `json:"printProvenance"`
+}
+
+type v1BatchResult struct {
+	Version uint    `json:"version"`
+	Error   *string `json:"error,omitempty"`
+	// Results are in the same order as the items of the query.
+	Results []*v1BatchItemResult `json:"results"`
+}
+
+type v1BatchItemResult struct {
+	ArtifactHash string `json:"artifactHash"`
+	*v1Result
+}
+
+// VerifyBatchHandlerV1 verifies the provenance of several artifacts. The
+// signature of each provenance is verified only once.
+func VerifyBatchHandlerV1(w http.ResponseWriter, r *http.Request) {
+	serveV1(w, r, verifyBatchHandlerV1)
+}
+
+func verifyBatchHandlerV1(r *http.Request) *v1BatchResult {
+	results := &v1BatchResult{
+		Version: 1,
+		Results: []*v1BatchItemResult{},
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		results.Error = toStringPtr(err)
+		return results
+	}
+
+	// Create a query.
+	query, err := batchQueryFromString(body)
+	if err != nil {
+		results.Error = toStringPtr(err)
+		return results
+	}
+
+	// Validate it.
+	if err := query.validate(); err != nil {
+		results.Error = toStringPtr(err)
+		return results
+	}
+
+	// Run the verifications, sharing the verification of the signatures.
+	ctx := verifiers.WithSignatureCache(context.Background())
+	for i := range query.Items {
+		item := &query.Items[i]
+		results.Results = append(results.Results, &v1BatchItemResult{
+			ArtifactHash: item.ArtifactHash,
+			v1Result:     query.verifyItem(ctx, item),
+		})
+	}
+	return results
+}
+
+// verifyItem verifies an item of the validated query.
+func (q *v1BatchQuery) verifyItem(ctx context.Context, item *v1BatchItem) *v1Result {
+	if item.Provenance < 0 || item.Provenance >= len(q.DsseEnvelopes) {
+		return v1ResultNew().withError(fmt.Errorf("%w: no provenance at index %d", errInvalid, item.Provenance))
+	}
+	return verifyQueryV1(ctx, &v1Query{
+		Source:          item.Source,
+		ArtifactHash:    item.ArtifactHash,
+		DsseEnvelope:    q.DsseEnvelopes[item.Provenance],
+		BuilderID:       item.BuilderID,
+		Tag:             item.Tag,
+		Branch:          item.Branch,
+		VersionedTag:    item.VersionedTag,
+		PrintProvenance: item.PrintProvenance,
+	})
+}
+
+func batchQueryFromString(content []byte) (*v1BatchQuery, error) {
+	var query v1BatchQuery
+	err := json.Unmarshal(content, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.DsseEnvelope != "" {
+		if len(query.DsseEnvelopes) > 0 {
+			return nil, fmt.Errorf("%w: provenanceContent and provenanceContents are mutually exclusive", errInvalid)
+		}
+		query.DsseEnvelopes = []string{query.DsseEnvelope}
+		query.DsseEnvelope = ""
+	}
+
+	for i, e := range query.DsseEnvelopes {
+		env, err := base64.StdEncoding.DecodeString(e)
+		if err != nil {
+			return nil, fmt.Errorf("%w: decoding payload %d", errInvalid, i)
+		}
+		query.DsseEnvelopes[i] = string(env)
+	}
+	return &query, nil
+}
+
+func (q *v1BatchQuery) validate() error {
+	if len(q.DsseEnvelopes) == 0 {
+		return fmt.Errorf("%w: empty provenanceContents", errInvalid)
+	}
+
+	if len(q.Items) == 0 {
+		return fmt.Errorf("%w: empty items", errInvalid)
+	}
+
+	if len(q.Items) > maxBatchItems {
+		return fmt.Errorf("%w: more than %d items", errInvalid, maxBatchItems)
+	}
+
+	// The items are validated individually.
+
+	return nil
+}
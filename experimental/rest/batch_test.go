@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+)
+
+func Test_batchQueryFromString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		content     string
+		provenances []string
+		expected    error
+	}{
+		{
+			name:        "single provenance",
+			content:     `{"provenanceContent": "YQ==", "items": [{"source": "github.com/org/repo", "artifactHash": "abcd"}]}`,
+			provenances: []string{"a"},
+		},
+		{
+			name:        "several provenances",
+			content:     `{"provenanceContents": ["YQ==", "Yg=="], "items": [{"source": "github.com/org/repo", "artifactHash": "abcd", "provenance": 1}]}`,
+			provenances: []string{"a", "b"},
+		},
+		{
+			name:     "single and several provenances",
+			content:  `{"provenanceContent": "YQ==", "provenanceContents": ["Yg=="], "items": [{"source": "github.com/org/repo", "artifactHash": "abcd"}]}`,
+			expected: errInvalid,
+		},
+		{
+			name:     "invalid provenance encoding",
+			content:  `{"provenanceContents": ["YQ==", "%"], "items": [{"source": "github.com/org/repo", "artifactHash": "abcd"}]}`,
+			expected: errInvalid,
+		},
+		{
+			name:     "no provenance",
+			content:  `{"items": [{"source": "github.com/org/repo", "artifactHash": "abcd"}]}`,
+			expected: errInvalid,
+		},
+		{
+			name:     "no items",
+			content:  `{"provenanceContent": "YQ==", "items": []}`,
+			expected: errInvalid,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := batchQueryFromString([]byte(tt.content))
+			if err == nil {
+				err = query.validate()
+			}
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf("unexpected error (-want +got): \n%s", cmp.Diff(tt.expected, err, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.provenances, query.DsseEnvelopes); diff != "" {
+				t.Errorf("unexpected provenances (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_verifyBatchHandlerV1(t *testing.T) {
+	// The trusted root is process-wide.
+	if err := verifiers.LoadTrustedRoot("../../verifiers/internal/gha/testdata/trusted_root.json"); err != nil {
+		t.Fatal(err)
+	}
+	provenance, err := os.ReadFile("../../cli/slsa-verifier/testdata/gha_container-based/v1.7.0/gha_container-based-binary-linux-amd64-v14.intoto.sigstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := "github.com/slsa-framework/example-package"
+	query := v1BatchQuery{
+		DsseEnvelope: base64.StdEncoding.EncodeToString(provenance),
+		Items: []v1BatchItem{
+			// The first item fails and must not fail the others.
+			{Source: source, ArtifactHash: strings.Repeat("0", 64)},
+			{Source: source, ArtifactHash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			{Source: "github.com/org/repo", ArtifactHash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			{Source: source, ArtifactHash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Provenance: 1},
+			{ArtifactHash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		},
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := verifyBatchHandlerV1(httptest.NewRequest("POST", "/v1/verify/batch", strings.NewReader(string(body))))
+	if results.Error != nil {
+		t.Fatalf("unexpected error: %s", *results.Error)
+	}
+	expected := []validation{validationFailure, validationSuccess, validationFailure, validationFailure, validationFailure}
+	var validations []validation
+	for _, r := range results.Results {
+		validations = append(validations, r.Validation)
+	}
+	if diff := cmp.Diff(expected, validations); diff != "" {
+		t.Errorf("unexpected validations (-want +got): \n%s", diff)
+	}
+	// The items are validated individually.
+	for _, i := range []int{3, 4} {
+		if e := results.Results[i].Error; e == nil || !strings.HasPrefix(*e, errInvalid.Error()) {
+			t.Errorf("unexpected error for item %d: %v", i, e)
+		}
+	}
+	if b := results.Results[1].BuilderID; !strings.HasPrefix(b, "https://github.com/slsa-framework/slsa-github-generator/") {
+		t.Errorf("unexpected builder ID: %s", b)
+	}
+}
+
+func Test_verifyBatchHandlerV1_builders(t *testing.T) {
+	// The trusted root is process-wide.
+	if err := verifiers.LoadTrustedRoot("../../verifiers/internal/gha/testdata/trusted_root.json"); err != nil {
+		t.Fatal(err)
+	}
+	provenance, err := os.ReadFile("../../cli/slsa-verifier/testdata/gha_container-based/v1.7.0/gha_container-based-binary-linux-amd64-v14.intoto.sigstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The GitHub provenance is verified with the certificate identities of
+	// each builder, so it is not shared with the GitLab items.
+	source := "github.com/slsa-framework/example-package"
+	artifactHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	gitlabBuilderID := "https://gitlab.com/gitlab-org/gitlab-runner/gitlab-hosted"
+	query := v1BatchQuery{
+		DsseEnvelope: base64.StdEncoding.EncodeToString(provenance),
+		Items: []v1BatchItem{
+			{Source: source, ArtifactHash: artifactHash},
+			{Source: source, ArtifactHash: artifactHash, BuilderID: &gitlabBuilderID},
+			{Source: source, ArtifactHash: artifactHash},
+		},
+	}
+	body, err := json.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := verifyBatchHandlerV1(httptest.NewRequest("POST", "/v1/verify/batch", strings.NewReader(string(body))))
+	if results.Error != nil {
+		t.Fatalf("unexpected error: %s", *results.Error)
+	}
+	expected := []validation{validationSuccess, validationFailure, validationSuccess}
+	var validations []validation
+	for _, r := range results.Results {
+		validations = append(validations, r.Validation)
+	}
+	if diff := cmp.Diff(expected, validations); diff != "" {
+		t.Errorf("unexpected validations (-want +got): \n%s", diff)
+	}
+	// The signature of the GitLab item is verified against the GitLab identities.
+	if e := results.Results[1].Error; e == nil || !strings.HasPrefix(*e, serrors.ErrorInvalidSignature.Error()) {
+		t.Errorf("unexpected error for the GitLab item: %v", e)
+	}
+}
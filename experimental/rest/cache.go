@@ -0,0 +1,252 @@
+package rest
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+// Store stores the cached verification results. Implementations may use an
+// external store shared by several instances of the service.
+type Store interface {
+	// Get returns the value stored for the key. It returns false if there
+	// is no value, or if it expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value for the key, for the ttl duration.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Cache caches the results of successful verifications. Failed
+// verifications are not cached, since they may be caused by
+// transient errors, e.g. when searching the transparency log.
+type Cache struct {
+	store Store
+	ttl   time.Duration
+}
+
+// NewCache returns a cache that keeps results in the store for the ttl duration.
+func NewCache(store Store, ttl time.Duration) *Cache {
+	return &Cache{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+var (
+	cacheMu sync.RWMutex
+	cache   *Cache
+)
+
+// SetCache sets the cache used by the REST handlers and the gRPC service.
+// A nil cache disables caching, which is the default.
+func SetCache(c *Cache) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache = c
+}
+
+func getCache() *Cache {
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
+	return cache
+}
+
+// The kinds of verification requests, as recorded in the cache keys.
+const (
+	kindArtifact = "artifact"
+	kindImage    = "image"
+	kindNpm      = "npm"
+)
+
+// cacheKey is the data identifying a verification request.
+type cacheKey struct {
+	Kind           string                  `json:"kind"`
+	Subject        string                  `json:"subject,omitempty"`
+	EnvelopeDigest string                  `json:"envelopeDigest,omitempty"`
+	ArtifactHash   string                  `json:"artifactHash"`
+	ProvenanceOpts *options.ProvenanceOpts `json:"provenanceOpts"`
+	BuilderID      *string                 `json:"builderID"`
+}
+
+// newCacheKey returns the key of a verification request. The provenance
+// options are normalized by their JSON encoding, so that the order of
+// the workflow inputs does not matter, while unset and empty values
+// are still distinct.
+func newCacheKey(kind, subject string, envelope []byte, artifactHash string,
+	provenanceOpts *options.ProvenanceOpts, builderOpts *options.BuilderOpts,
+) (string, error) {
+	k := cacheKey{
+		Kind:           kind,
+		Subject:        subject,
+		ArtifactHash:   artifactHash,
+		ProvenanceOpts: provenanceOpts,
+	}
+	if len(envelope) > 0 {
+		d := sha256.Sum256(envelope)
+		k.EnvelopeDigest = hex.EncodeToString(d[:])
+	}
+	if builderOpts != nil {
+		k.BuilderID = builderOpts.ExpectedID
+	}
+	b, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	d := sha256.Sum256(b)
+	return hex.EncodeToString(d[:]), nil
+}
+
+// cachedResult is the encoding of a verification result in the store.
+type cachedResult struct {
+	// Result is the verification result, without the builder ID.
+	Result    *utils.VerificationResult `json:"result"`
+	BuilderID string                    `json:"builderID"`
+}
+
+// cachedVerify returns the cached result of a verification request,
+// or runs the verification and caches its result. The cache is skipped
+// if the store fails.
+func cachedVerify(ctx context.Context, key string,
+	verify func() (*utils.VerificationResult, error),
+) (*utils.VerificationResult, error) {
+	c := getCache()
+	if c == nil {
+		return verify()
+	}
+
+	if b, ok, err := c.store.Get(ctx, key); err == nil && ok {
+		if result, err := decodeResult(b); err == nil {
+			return result, nil
+		}
+	}
+
+	result, err := verify()
+	if err != nil {
+		return nil, err
+	}
+	if b, err := encodeResult(result); err == nil {
+		_ = c.store.Set(ctx, key, b, c.ttl)
+	}
+	return result, nil
+}
+
+func encodeResult(result *utils.VerificationResult) ([]byte, error) {
+	r := *result
+	r.BuilderID = nil
+	c := cachedResult{Result: &r}
+	if result.BuilderID != nil {
+		c.BuilderID = result.BuilderID.String()
+	}
+	return json.Marshal(c)
+}
+
+func decodeResult(b []byte) (*utils.VerificationResult, error) {
+	var c cachedResult
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	result := c.Result
+	if result == nil {
+		result = &utils.VerificationResult{}
+	}
+	if c.BuilderID != "" {
+		builderID, err := utils.TrustedBuilderIDNew(c.BuilderID, false)
+		if err != nil {
+			return nil, err
+		}
+		result.BuilderID = builderID
+	}
+	return result, nil
+}
+
+// MemoryStore is an in-memory Store. It evicts the least recently used
+// entries when it is full.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	// lru is ordered from the most to the least recently used entry.
+	lru *list.List
+	now func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryStore returns an in-memory store holding up to maxEntries entries.
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get implements Store.Get.
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := e.Value.(*memoryEntry)
+	if !s.now().Before(entry.expires) {
+		s.remove(e)
+		return nil, false, nil
+	}
+	s.lru.MoveToFront(e)
+	return entry.value, true, nil
+}
+
+// Set implements Store.Set.
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxEntries <= 0 {
+		return nil
+	}
+	expires := s.now().Add(ttl)
+	if e, ok := s.entries[key]; ok {
+		entry := e.Value.(*memoryEntry)
+		entry.value = value
+		entry.expires = expires
+		s.lru.MoveToFront(e)
+		return nil
+	}
+	for s.lru.Len() >= s.maxEntries {
+		s.remove(s.lru.Back())
+	}
+	s.entries[key] = s.lru.PushFront(&memoryEntry{
+		key:     key,
+		value:   value,
+		expires: expires,
+	})
+	return nil
+}
+
+// Len returns the number of entries in the store, including expired
+// entries that have not been evicted yet.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+func (s *MemoryStore) remove(e *list.Element) {
+	s.lru.Remove(e)
+	delete(s.entries, e.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+func Test_MemoryStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	s := NewMemoryStore(2)
+	s.now = func() time.Time { return now }
+
+	if err := s.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := s.Set(ctx, "b", []byte("2"), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	// "a" becomes the most recently used entry, so "b" is evicted.
+	if v, ok, _ := s.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Errorf("unexpected value for a: %s, %v", v, ok)
+	}
+	if err := s.Set(ctx, "c", []byte("3"), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if _, ok, _ := s.Get(ctx, "b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if s.Len() != 2 {
+		t.Errorf("unexpected length: %d", s.Len())
+	}
+
+	// "a" expires.
+	now = now.Add(time.Minute)
+	if _, ok, _ := s.Get(ctx, "a"); ok {
+		t.Errorf("expected a to expire")
+	}
+	if v, ok, _ := s.Get(ctx, "c"); !ok || string(v) != "3" {
+		t.Errorf("unexpected value for c: %s, %v", v, ok)
+	}
+	if s.Len() != 1 {
+		t.Errorf("unexpected length: %d", s.Len())
+	}
+}
+
+func Test_newCacheKey(t *testing.T) {
+	t.Parallel()
+
+	branch := "main"
+	otherBranch := "dev"
+	empty := ""
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml"
+	opts := func(branch *string, inputs map[string]string) *options.ProvenanceOpts {
+		return &options.ProvenanceOpts{
+			ExpectedSourceURI:      "github.com/org/repo",
+			ExpectedDigest:         "abcd",
+			ExpectedBranch:         branch,
+			ExpectedWorkflowInputs: inputs,
+		}
+	}
+	key := func(envelope string, p *options.ProvenanceOpts, id *string) string {
+		k, err := newCacheKey("artifact", "", []byte(envelope), "abcd", p, &options.BuilderOpts{ExpectedID: id})
+		if err != nil {
+			t.Fatalf("newCacheKey: %v", err)
+		}
+		return k
+	}
+
+	base := key("envelope", opts(&branch, map[string]string{"a": "1", "b": "2"}), &builderID)
+	same := key("envelope", opts(&branch, map[string]string{"b": "2", "a": "1"}), &builderID)
+	if base != same {
+		t.Errorf("expected the same key for equal requests")
+	}
+
+	different := map[string]string{
+		"envelope":     key("other envelope", opts(&branch, map[string]string{"a": "1", "b": "2"}), &builderID),
+		"branch":       key("envelope", opts(&otherBranch, map[string]string{"a": "1", "b": "2"}), &builderID),
+		"empty branch": key("envelope", opts(&empty, map[string]string{"a": "1", "b": "2"}), &builderID),
+		"no branch":    key("envelope", opts(nil, map[string]string{"a": "1", "b": "2"}), &builderID),
+		"inputs":       key("envelope", opts(&branch, map[string]string{"a": "1"}), &builderID),
+		"builder":      key("envelope", opts(&branch, map[string]string{"a": "1", "b": "2"}), nil),
+	}
+	for name, k := range different {
+		if k == base {
+			t.Errorf("expected a different key for a different %s", name)
+		}
+	}
+}
+
+func Test_cachedVerify(t *testing.T) {
+	// Not parallel: the cache is global.
+	SetCache(NewCache(NewMemoryStore(10), time.Hour))
+	defer SetCache(nil)
+
+	ctx := context.Background()
+	builderID, err := utils.TrustedBuilderIDNew("https://github.com/org/repo/.github/workflows/builder.yml@refs/tags/v1.0.0", true)
+	if err != nil {
+		t.Fatalf("TrustedBuilderIDNew: %v", err)
+	}
+	expected := &utils.VerificationResult{
+		Statement:    []byte(`{"_type": "https://in-toto.io/Statement/v0.1"}`),
+		BuilderID:    builderID,
+		SourceURI:    "https://github.com/org/repo",
+		SourceCommit: "0123456789abcdef0123456789abcdef01234567",
+		Checks:       []string{utils.CheckSignature, utils.CheckSourceURI},
+		BuildLevel:   3,
+	}
+
+	calls := 0
+	verify := func() (*utils.VerificationResult, error) {
+		calls++
+		return expected, nil
+	}
+	for i := 0; i < 2; i++ {
+		result, err := cachedVerify(ctx, "success", verify)
+		if err != nil {
+			t.Fatalf("cachedVerify: %v", err)
+		}
+		if result.BuilderID.String() != builderID.String() {
+			t.Errorf("unexpected builder ID: %s", result.BuilderID.String())
+		}
+		if diff := cmp.Diff(expected, result, cmpopts.IgnoreFields(utils.VerificationResult{}, "BuilderID")); diff != "" {
+			t.Errorf("unexpected result (-want +got): \n%s", diff)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 verification, got %d", calls)
+	}
+
+	// Failures are not cached.
+	calls = 0
+	fail := func() (*utils.VerificationResult, error) {
+		calls++
+		return nil, serrors.ErrorMismatchSource
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cachedVerify(ctx, "failure", fail); !errors.Is(err, serrors.ErrorMismatchSource) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 verifications, got %d", calls)
+	}
+}
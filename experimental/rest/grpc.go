@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	slsaverifierv1 "github.com/slsa-framework/slsa-verifier/v2/experimental/proto/slsaverifier/v1"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+// errorCodes maps the categories of verification errors to the
+// typed error codes of the API.
+var errorCodes = map[serrors.Category]slsaverifierv1.ErrorCode{
+	serrors.CategorySignature:   slsaverifierv1.ErrorCode_ERROR_CODE_SIGNATURE,
+	serrors.CategoryBuilder:     slsaverifierv1.ErrorCode_ERROR_CODE_BUILDER,
+	serrors.CategorySource:      slsaverifierv1.ErrorCode_ERROR_CODE_SOURCE,
+	serrors.CategorySubject:     slsaverifierv1.ErrorCode_ERROR_CODE_SUBJECT,
+	serrors.CategoryProvenance:  slsaverifierv1.ErrorCode_ERROR_CODE_PROVENANCE,
+	serrors.CategoryUnsupported: slsaverifierv1.ErrorCode_ERROR_CODE_UNSUPPORTED,
+	serrors.CategoryInternal:    slsaverifierv1.ErrorCode_ERROR_CODE_INTERNAL,
+}
+
+type grpcServer struct {
+	slsaverifierv1.UnimplementedSLSAVerifierServer
+}
+
+// NewGRPCServer returns the implementation of the SLSAVerifier gRPC service.
+// Requests are validated with the same rules as the REST handlers.
+func NewGRPCServer() slsaverifierv1.SLSAVerifierServer {
+	return &grpcServer{}
+}
+
+// VerifyArtifact implements SLSAVerifierServer.VerifyArtifact.
+func (s *grpcServer) VerifyArtifact(ctx context.Context,
+	req *slsaverifierv1.VerifyArtifactRequest,
+) (*slsaverifierv1.VerifyResponse, error) {
+	query := &v1Query{
+		Source:       req.GetSource(),
+		ArtifactHash: req.GetArtifactHash(),
+		DsseEnvelope: string(req.GetProvenanceContent()),
+		BuilderID:    req.BuilderId,
+		Tag:          req.Tag,
+		Branch:       req.Branch,
+		VersionedTag: req.VersionedTag,
+	}
+	result, err := validateAndVerify(ctx, query)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newVerifyResponse(result, req.GetPrintProvenance()), nil
+}
+
+// VerifyImage implements SLSAVerifierServer.VerifyImage.
+func (s *grpcServer) VerifyImage(ctx context.Context,
+	req *slsaverifierv1.VerifyImageRequest,
+) (*slsaverifierv1.VerifyResponse, error) {
+	query := &v1ImageQuery{
+		Source:       req.GetSource(),
+		Image:        req.GetImage(),
+		DsseEnvelope: string(req.GetProvenanceContent()),
+		BuilderID:    req.BuilderId,
+		Tag:          req.Tag,
+		Branch:       req.Branch,
+		VersionedTag: req.VersionedTag,
+	}
+	result, err := validateAndVerify(ctx, query)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newVerifyResponse(result, req.GetPrintProvenance()), nil
+}
+
+// VerifyNpmPackage implements SLSAVerifierServer.VerifyNpmPackage.
+func (s *grpcServer) VerifyNpmPackage(ctx context.Context,
+	req *slsaverifierv1.VerifyNpmPackageRequest,
+) (*slsaverifierv1.VerifyResponse, error) {
+	query := &v1NpmQuery{
+		Source:         req.GetSource(),
+		BuilderID:      req.GetBuilderId(),
+		PackageName:    req.GetPackageName(),
+		PackageVersion: req.GetPackageVersion(),
+		TarballHash:    req.GetTarballHash(),
+		Attestations:   string(req.GetAttestationsContent()),
+		Tag:            req.Tag,
+		Branch:         req.Branch,
+		VersionedTag:   req.VersionedTag,
+	}
+	result, err := validateAndVerify(ctx, query)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newVerifyResponse(result, req.GetPrintProvenance()), nil
+}
+
+func newVerifyResponse(result *utils.VerificationResult, printProvenance bool) *slsaverifierv1.VerifyResponse {
+	resp := &slsaverifierv1.VerifyResponse{
+		BuilderId: result.BuilderID.String(),
+	}
+	if printProvenance {
+		resp.ProvenanceContent = result.Statement
+	}
+	return resp
+}
+
+// grpcError returns the error status of a failed verification, with the
+// typed error code and the mismatching values, if any, in its details.
+func grpcError(err error) error {
+	failure := &slsaverifierv1.VerificationFailure{}
+	var code codes.Code
+	switch category := serrors.CategoryOf(err); {
+	case errors.Is(err, errInvalid):
+		code = codes.InvalidArgument
+		failure.Code = slsaverifierv1.ErrorCode_ERROR_CODE_INVALID_REQUEST
+	case category == serrors.CategoryUnsupported:
+		code = codes.Unimplemented
+		failure.Code = errorCodes[category]
+	case category == serrors.CategoryInternal:
+		code = codes.Internal
+		failure.Code = errorCodes[category]
+	case category == serrors.CategoryOther:
+		code = codes.Unknown
+	default:
+		// The provenance did not pass verification.
+		code = codes.FailedPrecondition
+		failure.Code = errorCodes[category]
+	}
+
+	var verr *serrors.VerificationError
+	if errors.As(err, &verr) {
+		failure.Check = verr.Check
+		failure.Field = verr.Field
+		failure.Expected = verr.Expected
+		failure.Actual = verr.Actual
+	}
+
+	st, derr := status.New(code, err.Error()).WithDetails(failure)
+	if derr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,332 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	slsaverifierv1 "github.com/slsa-framework/slsa-verifier/v2/experimental/proto/slsaverifier/v1"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+)
+
+// grpcFailure returns the code and the failure details of a gRPC error.
+func grpcFailure(t *testing.T, err error) (codes.Code, *slsaverifierv1.VerificationFailure) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("not a status error: %v", err)
+	}
+	for _, d := range st.Details() {
+		if failure, ok := d.(*slsaverifierv1.VerificationFailure); ok {
+			return st.Code(), failure
+		}
+	}
+	return st.Code(), nil
+}
+
+func Test_grpcError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		err      error
+		code     codes.Code
+		expected *slsaverifierv1.VerificationFailure
+	}{
+		{
+			name:     "invalid request",
+			err:      fmt.Errorf("%w: empty source", errInvalid),
+			code:     codes.InvalidArgument,
+			expected: &slsaverifierv1.VerificationFailure{Code: slsaverifierv1.ErrorCode_ERROR_CODE_INVALID_REQUEST},
+		},
+		{
+			name:     "signature",
+			err:      fmt.Errorf("%w: bad signature", serrors.ErrorInvalidSignature),
+			code:     codes.FailedPrecondition,
+			expected: &slsaverifierv1.VerificationFailure{Code: slsaverifierv1.ErrorCode_ERROR_CODE_SIGNATURE},
+		},
+		{
+			name:     "builder",
+			err:      serrors.ErrorUntrustedReusableWorkflow,
+			code:     codes.FailedPrecondition,
+			expected: &slsaverifierv1.VerificationFailure{Code: slsaverifierv1.ErrorCode_ERROR_CODE_BUILDER},
+		},
+		{
+			name: "source mismatch",
+			err: &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchSource,
+				Check:    "source-uri",
+				Field:    "configSource.uri",
+				Expected: "github.com/org/repo",
+				Actual:   "github.com/org/other",
+			},
+			code: codes.FailedPrecondition,
+			expected: &slsaverifierv1.VerificationFailure{
+				Code:     slsaverifierv1.ErrorCode_ERROR_CODE_SOURCE,
+				Check:    "source-uri",
+				Field:    "configSource.uri",
+				Expected: "github.com/org/repo",
+				Actual:   "github.com/org/other",
+			},
+		},
+		{
+			name:     "subject",
+			err:      serrors.ErrorMismatchHash,
+			code:     codes.FailedPrecondition,
+			expected: &slsaverifierv1.VerificationFailure{Code: slsaverifierv1.ErrorCode_ERROR_CODE_SUBJECT},
+		},
+		{
+			name:     "provenance",
+			err:      serrors.ErrorInvalidDssePayload,
+			code:     codes.FailedPrecondition,
+			expected: &slsaverifierv1.VerificationFailure{Code: slsaverifierv1.ErrorCode_ERROR_CODE_PROVENANCE},
+		},
+		{
+			name:     "unsupported",
+			err:      fmt.Errorf("%w: tag for npm packages", serrors.ErrorNotSupported),
+			code:     codes.Unimplemented,
+			expected: &slsaverifierv1.VerificationFailure{Code: slsaverifierv1.ErrorCode_ERROR_CODE_UNSUPPORTED},
+		},
+		{
+			name:     "internal",
+			err:      serrors.ErrorInternal,
+			code:     codes.Internal,
+			expected: &slsaverifierv1.VerificationFailure{Code: slsaverifierv1.ErrorCode_ERROR_CODE_INTERNAL},
+		},
+		{
+			name:     "other",
+			err:      errors.New("other"),
+			code:     codes.Unknown,
+			expected: &slsaverifierv1.VerificationFailure{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := grpcError(tt.err)
+			code, failure := grpcFailure(t, err)
+			if code != tt.code {
+				t.Errorf("unexpected code: %v, expected %v", code, tt.code)
+			}
+			if diff := cmp.Diff(tt.expected, failure, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected failure (-want +got): \n%s", diff)
+			}
+			if st, _ := status.FromError(err); st.Message() != tt.err.Error() {
+				t.Errorf("unexpected message: %q", st.Message())
+			}
+		})
+	}
+}
+
+func Test_errorCodes(t *testing.T) {
+	t.Parallel()
+	// Every category but CategoryOther has its own error code.
+	categories := []serrors.Category{
+		serrors.CategorySignature, serrors.CategoryBuilder,
+		serrors.CategorySource, serrors.CategorySubject,
+		serrors.CategoryProvenance, serrors.CategoryUnsupported,
+		serrors.CategoryInternal,
+	}
+	seen := make(map[slsaverifierv1.ErrorCode]serrors.Category)
+	for _, c := range categories {
+		code, ok := errorCodes[c]
+		if !ok || code == slsaverifierv1.ErrorCode_ERROR_CODE_UNSPECIFIED ||
+			code == slsaverifierv1.ErrorCode_ERROR_CODE_INVALID_REQUEST {
+			t.Errorf("no error code for category %s", c)
+			continue
+		}
+		if other, ok := seen[code]; ok {
+			t.Errorf("categories %s and %s share the error code %v", c, other, code)
+		}
+		seen[code] = c
+	}
+	if _, ok := errorCodes[serrors.CategoryOther]; ok {
+		t.Errorf("unexpected error code for category %s", serrors.CategoryOther)
+	}
+}
+
+func Test_grpcServer_VerifyArtifact(t *testing.T) {
+	// The trusted root is process-wide.
+	if err := verifiers.LoadTrustedRoot("../../verifiers/internal/gha/testdata/trusted_root.json"); err != nil {
+		t.Fatal(err)
+	}
+	provenance, err := os.ReadFile("../../cli/slsa-verifier/testdata/gha_container-based/v1.7.0/gha_container-based-binary-linux-amd64-v14.intoto.sigstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := "github.com/slsa-framework/example-package"
+	artifactHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_container-based_slsa3.yml@refs/tags/v1.7.0"
+	tests := []struct {
+		name            string
+		req             *slsaverifierv1.VerifyArtifactRequest
+		code            codes.Code
+		printProvenance bool
+	}{
+		{
+			name: "valid",
+			req: &slsaverifierv1.VerifyArtifactRequest{
+				Source:            source,
+				ArtifactHash:      artifactHash,
+				ProvenanceContent: provenance,
+			},
+		},
+		{
+			name: "print provenance",
+			req: &slsaverifierv1.VerifyArtifactRequest{
+				Source:            source,
+				ArtifactHash:      artifactHash,
+				ProvenanceContent: provenance,
+				PrintProvenance:   true,
+			},
+			printProvenance: true,
+		},
+		{
+			name: "mismatched source",
+			req: &slsaverifierv1.VerifyArtifactRequest{
+				Source:            "github.com/org/repo",
+				ArtifactHash:      artifactHash,
+				ProvenanceContent: provenance,
+			},
+			code: codes.FailedPrecondition,
+		},
+		{
+			name: "empty source",
+			req: &slsaverifierv1.VerifyArtifactRequest{
+				ArtifactHash:      artifactHash,
+				ProvenanceContent: provenance,
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewGRPCServer().VerifyArtifact(context.Background(), tt.req)
+			if tt.code != codes.OK {
+				if code, _ := grpcFailure(t, err); code != tt.code {
+					t.Errorf("unexpected code: %v, expected %v", code, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.GetBuilderId() != builderID {
+				t.Errorf("unexpected builder ID: %s", resp.GetBuilderId())
+			}
+			if (len(resp.GetProvenanceContent()) > 0) != tt.printProvenance {
+				t.Errorf("unexpected provenance: %s", resp.GetProvenanceContent())
+			}
+		})
+	}
+}
+
+func Test_grpcServer_VerifyImage(t *testing.T) {
+	t.Parallel()
+	tag, versionedTag := "v1.2.3", "v1"
+	tests := []struct {
+		name string
+		req  *slsaverifierv1.VerifyImageRequest
+		code codes.Code
+	}{
+		{
+			name: "empty source",
+			req:  &slsaverifierv1.VerifyImageRequest{Image: "ghcr.io/org/repo@sha256:abcd"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "empty image",
+			req:  &slsaverifierv1.VerifyImageRequest{Source: "github.com/org/repo"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "tag and versioned tag",
+			req: &slsaverifierv1.VerifyImageRequest{
+				Source:       "github.com/org/repo",
+				Image:        "ghcr.io/org/repo@sha256:abcd",
+				Tag:          &tag,
+				VersionedTag: &versionedTag,
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewGRPCServer().VerifyImage(context.Background(), tt.req)
+			if code, _ := grpcFailure(t, err); code != tt.code {
+				t.Errorf("unexpected code: %v, expected %v", code, tt.code)
+			}
+		})
+	}
+}
+
+func Test_grpcServer_VerifyNpmPackage(t *testing.T) {
+	tag := "v1.2.3"
+	valid := func() *slsaverifierv1.VerifyNpmPackageRequest {
+		return &slsaverifierv1.VerifyNpmPackageRequest{
+			Source:              "github.com/org/repo",
+			BuilderId:           "https://github.com/actions/runner/github-hosted",
+			PackageName:         "pkg",
+			PackageVersion:      "1.0.0",
+			TarballHash:         "abcd",
+			AttestationsContent: []byte("a"),
+		}
+	}
+	tests := []struct {
+		name         string
+		req          func() *slsaverifierv1.VerifyNpmPackageRequest
+		experimental string
+		code         codes.Code
+	}{
+		{
+			name: "not experimental",
+			req:  valid,
+			code: codes.Unimplemented,
+		},
+		{
+			name: "empty source",
+			req: func() *slsaverifierv1.VerifyNpmPackageRequest {
+				req := valid()
+				req.Source = ""
+				return req
+			},
+			experimental: "1",
+			code:         codes.InvalidArgument,
+		},
+		{
+			name: "tag",
+			req: func() *slsaverifierv1.VerifyNpmPackageRequest {
+				req := valid()
+				req.Tag = &tag
+				return req
+			},
+			experimental: "1",
+			code:         codes.Unimplemented,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SLSA_VERIFIER_EXPERIMENTAL", tt.experimental)
+
+			_, err := NewGRPCServer().VerifyNpmPackage(context.Background(), tt.req())
+			if code, _ := grpcFailure(t, err); code != tt.code {
+				t.Errorf("unexpected code: %v, expected %v", code, tt.code)
+			}
+		})
+	}
+}
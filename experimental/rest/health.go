@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+)
+
+// HealthzHandler reports that the service is alive.
+func HealthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// ReadyzHandler reports whether the service is ready to verify requests.
+// It fails until the Sigstore trusted root has been loaded.
+func ReadyzHandler(w http.ResponseWriter, r *http.Request) {
+	if !verifiers.TrustedRootLoaded() {
+		http.Error(w, "trusted root not loaded", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
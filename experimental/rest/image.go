@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils/container"
+)
+
+type v1ImageQuery struct {
+	// Compulsory fields.
+	Source string `json:"source"`
+	// Image is an immutable reference to the image, i.e. by digest.
+	Image string `json:"image"`
+	// Optional fields.
+	// DsseEnvelope may be empty if the provenance is attached to the image
+	// in the registry.
+	DsseEnvelope    string  `json:"provenanceContent"`
+	BuilderID       *string `json:"builderID"`
+	Tag             *string `json:"tag"`
+	Branch          *string `json:"branch"`
+	VersionedTag    *string `json:"versionedTag"`
+	PrintProvenance *bool   `json:"printProvenance"`
+}
+
+// VerifyImageHandlerV1 verifies the provenance of a container image.
+func VerifyImageHandlerV1(w http.ResponseWriter, r *http.Request) {
+	serveV1(w, r, verifyImageHandlerV1)
+}
+
+func verifyImageHandlerV1(r *http.Request) *v1Result {
+	return verifyRequestV1(r, imageQueryFromString)
+}
+
+// verify verifies the validated query.
+func (q *v1ImageQuery) verify(ctx context.Context) (result *utils.VerificationResult, err error) {
+	builderOpts := &options.BuilderOpts{
+		ExpectedID: q.BuilderID,
+	}
+
+	ctx, done := observeVerification(ctx, verifierLabel(builderOpts))
+	defer func() { done(err) }()
+
+	// Verify that the reference is immutable.
+	digest, err := container.GetDigestFromImmutableReference(q.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	provenanceOpts := &options.ProvenanceOpts{
+		ExpectedSourceURI:    q.Source,
+		ExpectedBranch:       q.Branch,
+		ExpectedDigest:       digest,
+		ExpectedVersionedTag: q.VersionedTag,
+		ExpectedTag:          q.Tag,
+	}
+
+	var provenance []byte
+	if q.DsseEnvelope != "" {
+		provenance = []byte(q.DsseEnvelope)
+	}
+
+	key, err := newCacheKey(kindImage, q.Image, provenance, digest,
+		provenanceOpts, builderOpts)
+	if err != nil {
+		return nil, err
+	}
+	return cachedVerify(ctx, key, func() (*utils.VerificationResult, error) {
+		return verifiers.VerifyImage(ctx, q.Image, provenance,
+			provenanceOpts, builderOpts, nil)
+	})
+}
+
+func imageQueryFromString(content []byte) (*v1ImageQuery, error) {
+	var query v1ImageQuery
+	err := json.Unmarshal(content, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := base64.StdEncoding.DecodeString(query.DsseEnvelope)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decoding payload", errInvalid)
+	}
+	query.DsseEnvelope = string(env)
+	return &query, nil
+}
+
+func (q *v1ImageQuery) printProvenance() bool {
+	return q.PrintProvenance != nil && *q.PrintProvenance
+}
+
+func (q *v1ImageQuery) validate() error {
+	if q.Source == "" {
+		return fmt.Errorf("%w: empty source", errInvalid)
+	}
+
+	if q.Image == "" {
+		return fmt.Errorf("%w: empty image", errInvalid)
+	}
+
+	if q.Tag != nil && q.VersionedTag != nil {
+		return fmt.Errorf("%w: tag and versionedTag are mutually exclusive", errInvalid)
+	}
+
+	// BuilderID and the provenance are optional, so not additional validation needed.
+
+	return nil
+}
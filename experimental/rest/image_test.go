@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_v1ImageQuery_validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		content  string
+		expected error
+	}{
+		{
+			name:    "valid",
+			content: `{"source": "github.com/org/repo", "image": "ghcr.io/org/repo@sha256:abcd", "provenanceContent": "YQ=="}`,
+		},
+		{
+			name:    "provenance in the registry",
+			content: `{"source": "github.com/org/repo", "image": "ghcr.io/org/repo@sha256:abcd"}`,
+		},
+		{
+			name:    "tag",
+			content: `{"source": "github.com/org/repo", "image": "ghcr.io/org/repo@sha256:abcd", "tag": "v1.2.3"}`,
+		},
+		{
+			name:     "empty source",
+			content:  `{"image": "ghcr.io/org/repo@sha256:abcd"}`,
+			expected: errInvalid,
+		},
+		{
+			name:     "empty image",
+			content:  `{"source": "github.com/org/repo"}`,
+			expected: errInvalid,
+		},
+		{
+			name:     "tag and versioned tag",
+			content:  `{"source": "github.com/org/repo", "image": "ghcr.io/org/repo@sha256:abcd", "tag": "v1.2.3", "versionedTag": "v1"}`,
+			expected: errInvalid,
+		},
+		{
+			name:     "invalid provenance encoding",
+			content:  `{"source": "github.com/org/repo", "image": "ghcr.io/org/repo@sha256:abcd", "provenanceContent": "%"}`,
+			expected: errInvalid,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := imageQueryFromString([]byte(tt.content))
+			if err == nil {
+				err = query.validate()
+			}
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf("unexpected error (-want +got): \n%s", cmp.Diff(tt.expected, err, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+)
+
+// verifierUnknown is the verifier reported in the metrics when no
+// verifier supports the builder of the request.
+const verifierUnknown = "unknown"
+
+const metricsNamespace = "slsa_verifier"
+
+var (
+	verificationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "verifications_total",
+		Help:      "Number of verifications, by verifier, result and error category.",
+	}, []string{"verifier", "result", "category"})
+
+	verificationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "verification_duration_seconds",
+		Help:      "Duration of verifications, by verifier.",
+		// From 10ms to about 40s.
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 13),
+	}, []string{"verifier"})
+
+	rekorRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "rekor_request_duration_seconds",
+		Help:      "Duration of the requests to the Rekor API, by operation. The requests made by cosign to verify images are not measured.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	rekorRequestFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "rekor_request_failures_total",
+		Help:      "Number of failed requests to the Rekor API, by operation. The requests made by cosign to verify images are not measured.",
+	}, []string{"operation"})
+
+	registry = newRegistry()
+)
+
+func newRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		verificationsTotal,
+		verificationDuration,
+		rekorRequestDuration,
+		rekorRequestFailures,
+	)
+	return r
+}
+
+// MetricsHandler serves the metrics of the service in the Prometheus format.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// verifierLabel returns the name of the verifier selected for the builder,
+// as reported in the metrics.
+func verifierLabel(builderOpts *options.BuilderOpts) string {
+	name, err := verifiers.VerifierName(builderOpts)
+	if err != nil {
+		return verifierUnknown
+	}
+	return name
+}
+
+// observeVerification starts recording a verification by the verifier.
+// The returned context records the requests sent to Rekor by the
+// verifier, and done must be called with the result of the verification.
+// The requests that cosign sends to Rekor to verify images are not
+// recorded, since cosign does not report them.
+func observeVerification(ctx context.Context, verifier string) (context.Context, func(err error)) {
+	parent := events.ObserverFromContext(ctx)
+	ctx = events.WithObserver(ctx, events.ObserverFunc(func(ctx context.Context, e events.Event) {
+		if r, ok := e.(events.RekorRequest); ok {
+			rekorRequestDuration.WithLabelValues(r.Operation).Observe(r.Duration.Seconds())
+			if r.Err != nil {
+				rekorRequestFailures.WithLabelValues(r.Operation).Inc()
+			}
+		}
+		if parent != nil {
+			parent.OnEvent(ctx, e)
+		}
+	}))
+
+	start := time.Now()
+	return ctx, func(err error) {
+		verificationDuration.WithLabelValues(verifier).Observe(time.Since(start).Seconds())
+		result, category := string(validationSuccess), ""
+		if err != nil {
+			result, category = string(validationFailure), string(serrors.CategoryOf(err))
+		}
+		verificationsTotal.WithLabelValues(verifier, result, category).Inc()
+	}
+}
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+func Test_observeVerification(t *testing.T) {
+	t.Parallel()
+
+	// Use a verifier name unused by the other tests.
+	verifier := "test"
+	var forwarded []events.Event
+	ctx := events.WithObserver(context.Background(),
+		events.ObserverFunc(func(_ context.Context, e events.Event) {
+			forwarded = append(forwarded, e)
+		}))
+
+	ctx, done := observeVerification(ctx, verifier)
+	events.Emit(ctx, events.RekorRequest{Operation: "TestSearch", Duration: time.Second})
+	events.Emit(ctx, events.RekorRequest{Operation: "TestSearch", Err: errors.New("unavailable")})
+	done(serrors.ErrorMismatchSource)
+
+	_, done = observeVerification(context.Background(), verifier)
+	done(nil)
+
+	if len(forwarded) != 2 {
+		t.Errorf("expected 2 events forwarded to the parent observer, got %d", len(forwarded))
+	}
+	if n := testutil.ToFloat64(rekorRequestFailures.WithLabelValues("TestSearch")); n != 1 {
+		t.Errorf("unexpected number of Rekor failures: %v", n)
+	}
+	if n := testutil.ToFloat64(verificationsTotal.WithLabelValues(verifier, "failure", "source")); n != 1 {
+		t.Errorf("unexpected number of failed verifications: %v", n)
+	}
+	if n := testutil.ToFloat64(verificationsTotal.WithLabelValues(verifier, "success", "")); n != 1 {
+		t.Errorf("unexpected number of successful verifications: %v", n)
+	}
+}
+
+func Test_verifierLabel(t *testing.T) {
+	t.Parallel()
+
+	empty := ""
+	tests := []struct {
+		name      string
+		builderID *string
+		expected  string
+	}{
+		{
+			name:     "default verifier",
+			expected: "GHA",
+		},
+		{
+			name:      "empty builder ID",
+			builderID: &empty,
+			expected:  "GHA",
+		},
+		{
+			name:      "GitHub Actions builder",
+			builderID: stringPtr("https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml"),
+			expected:  "GHA",
+		},
+		{
+			name:      "Cloud Build builder",
+			builderID: stringPtr("https://cloudbuild.googleapis.com/GoogleHostedWorker"),
+			expected:  "GCB",
+		},
+		{
+			name:      "GitLab CI builder",
+			builderID: stringPtr("https://gitlab.com/gitlab-org/gitlab-runner/gitlab-hosted"),
+			expected:  "GitLab",
+		},
+		{
+			name:      "unsupported builder",
+			builderID: stringPtr("https://example.com/builder"),
+			expected:  verifierUnknown,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := verifierLabel(&options.BuilderOpts{ExpectedID: tt.builderID}); got != tt.expected {
+				t.Errorf("unexpected verifier: %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
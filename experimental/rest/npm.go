@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+type v1NpmQuery struct {
+	// Compulsory fields.
+	Source         string `json:"source"`
+	BuilderID      string `json:"builderID"`
+	PackageName    string `json:"packageName"`
+	PackageVersion string `json:"packageVersion"`
+	// TarballHash is the hex-encoded sha512 digest of the package tarball.
+	TarballHash  string `json:"tarballHash"`
+	Attestations string `json:"attestationsContent"`
+	// Optional fields.
+	PrintProvenance *bool `json:"printProvenance"`
+	// Optional fields, not supported for npm packages.
+	Tag          *string `json:"tag"`
+	Branch       *string `json:"branch"`
+	VersionedTag *string `json:"versionedTag"`
+}
+
+// VerifyNpmHandlerV1 verifies the attestations of an npm package tarball.
+func VerifyNpmHandlerV1(w http.ResponseWriter, r *http.Request) {
+	serveV1(w, r, verifyNpmHandlerV1)
+}
+
+func verifyNpmHandlerV1(r *http.Request) *v1Result {
+	return verifyRequestV1(r, npmQueryFromString)
+}
+
+// verify verifies the validated query.
+func (q *v1NpmQuery) verify(ctx context.Context) (result *utils.VerificationResult, err error) {
+	provenanceOpts := &options.ProvenanceOpts{
+		ExpectedSourceURI:      q.Source,
+		ExpectedDigest:         q.TarballHash,
+		ExpectedPackageName:    &q.PackageName,
+		ExpectedPackageVersion: &q.PackageVersion,
+	}
+
+	builderOpts := &options.BuilderOpts{
+		ExpectedID: &q.BuilderID,
+	}
+
+	ctx, done := observeVerification(ctx, verifierLabel(builderOpts))
+	defer func() { done(err) }()
+
+	key, err := newCacheKey(kindNpm, "", []byte(q.Attestations), q.TarballHash,
+		provenanceOpts, builderOpts)
+	if err != nil {
+		return nil, err
+	}
+	return cachedVerify(ctx, key, func() (*utils.VerificationResult, error) {
+		return verifiers.VerifyNpmPackage(ctx, []byte(q.Attestations),
+			q.TarballHash, provenanceOpts, builderOpts, nil)
+	})
+}
+
+func npmQueryFromString(content []byte) (*v1NpmQuery, error) {
+	var query v1NpmQuery
+	err := json.Unmarshal(content, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	attestations, err := base64.StdEncoding.DecodeString(query.Attestations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decoding attestations", errInvalid)
+	}
+	query.Attestations = string(attestations)
+	return &query, nil
+}
+
+func (q *v1NpmQuery) printProvenance() bool {
+	return q.PrintProvenance != nil && *q.PrintProvenance
+}
+
+func (q *v1NpmQuery) validate() error {
+	// Same as the verify-npm-package command.
+	if !options.ExperimentalEnabled() {
+		return fmt.Errorf("%w: npm packages are only supported in SLSA_VERIFIER_EXPERIMENTAL mode",
+			serrors.ErrorNotSupported)
+	}
+
+	if q.Source == "" {
+		return fmt.Errorf("%w: empty source", errInvalid)
+	}
+
+	if q.BuilderID == "" {
+		return fmt.Errorf("%w: empty builderID", errInvalid)
+	}
+
+	if q.PackageName == "" {
+		return fmt.Errorf("%w: empty packageName", errInvalid)
+	}
+
+	if q.PackageVersion == "" {
+		return fmt.Errorf("%w: empty packageVersion", errInvalid)
+	}
+
+	if q.TarballHash == "" {
+		return fmt.Errorf("%w: empty tarballHash", errInvalid)
+	}
+
+	if q.Attestations == "" {
+		return fmt.Errorf("%w: empty attestationsContent", errInvalid)
+	}
+
+	if q.Tag != nil {
+		return fmt.Errorf("%w: tag for npm packages", serrors.ErrorNotSupported)
+	}
+
+	if q.Branch != nil {
+		return fmt.Errorf("%w: branch for npm packages", serrors.ErrorNotSupported)
+	}
+
+	if q.VersionedTag != nil {
+		return fmt.Errorf("%w: versionedTag for npm packages", serrors.ErrorNotSupported)
+	}
+
+	return nil
+}
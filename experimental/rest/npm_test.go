@@ -0,0 +1,105 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+)
+
+func Test_v1NpmQuery_validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		experimental string
+		expected     error
+	}{
+		{
+			name:         "valid",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ=="}`,
+			experimental: "1",
+		},
+		{
+			name:     "not experimental",
+			content:  `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ=="}`,
+			expected: serrors.ErrorNotSupported,
+		},
+		{
+			name:         "empty source",
+			content:      `{"builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ=="}`,
+			experimental: "1",
+			expected:     errInvalid,
+		},
+		{
+			name:         "empty builder ID",
+			content:      `{"source": "github.com/org/repo", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ=="}`,
+			experimental: "1",
+			expected:     errInvalid,
+		},
+		{
+			name:         "empty package name",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ=="}`,
+			experimental: "1",
+			expected:     errInvalid,
+		},
+		{
+			name:         "empty package version",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "tarballHash": "abcd", "attestationsContent": "YQ=="}`,
+			experimental: "1",
+			expected:     errInvalid,
+		},
+		{
+			name:         "empty tarball hash",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "attestationsContent": "YQ=="}`,
+			experimental: "1",
+			expected:     errInvalid,
+		},
+		{
+			name:         "empty attestations",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd"}`,
+			experimental: "1",
+			expected:     errInvalid,
+		},
+		{
+			name:         "invalid attestations encoding",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "%"}`,
+			experimental: "1",
+			expected:     errInvalid,
+		},
+		{
+			name:         "tag",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ==", "tag": "v1.0.0"}`,
+			experimental: "1",
+			expected:     serrors.ErrorNotSupported,
+		},
+		{
+			name:         "branch",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ==", "branch": "main"}`,
+			experimental: "1",
+			expected:     serrors.ErrorNotSupported,
+		},
+		{
+			name:         "versioned tag",
+			content:      `{"source": "github.com/org/repo", "builderID": "https://github.com/actions/runner/github-hosted", "packageName": "pkg", "packageVersion": "1.0.0", "tarballHash": "abcd", "attestationsContent": "YQ==", "versionedTag": "v1"}`,
+			experimental: "1",
+			expected:     serrors.ErrorNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			// The experimental mode is set in the environment.
+			t.Setenv("SLSA_VERIFIER_EXPERIMENTAL", tt.experimental)
+
+			query, err := npmQueryFromString([]byte(tt.content))
+			if err == nil {
+				err = query.validate()
+			}
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf("unexpected error (-want +got): \n%s", cmp.Diff(tt.expected, err, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
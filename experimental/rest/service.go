@@ -11,6 +11,7 @@ import (
 
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
 var errInvalid = errors.New("invalid")
@@ -44,12 +45,17 @@ type v1Result struct {
 }
 
 func VerifyHandlerV1(w http.ResponseWriter, r *http.Request) {
+	serveV1(w, r, verifyHandlerV1)
+}
+
+// serveV1 runs the verification of a request and writes its result.
+func serveV1[T any](w http.ResponseWriter, r *http.Request, verify func(r *http.Request) T) {
 	if r == nil {
 		http.Error(w, "empty request", http.StatusInternalServerError)
 		return
 	}
 
-	results := verifyHandlerV1(r)
+	results := verify(r)
 	encoder := json.NewEncoder(w)
 	if err := encoder.Encode(results); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -57,6 +63,16 @@ func VerifyHandlerV1(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// readBody reads and closes the body of the request.
+func readBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	return body, nil
+}
+
 func toStringPtr(e error) *string {
 	if e != nil {
 		s := e.Error()
@@ -96,50 +112,84 @@ func (r *v1Result) withIntotoStatement(c []byte) *v1Result {
 }
 
 func verifyHandlerV1(r *http.Request) *v1Result {
-	results := v1ResultNew()
+	return verifyRequestV1(r, queryFromString)
+}
 
-	body, err := io.ReadAll(r.Body)
+// verificationQuery is a request to verify an artifact, an image or an
+// npm package.
+type verificationQuery interface {
+	validate() error
+	verify(ctx context.Context) (*utils.VerificationResult, error)
+	printProvenance() bool
+}
+
+// verifyRequestV1 reads the query of the request with fromString,
+// and returns the result of its verification.
+func verifyRequestV1[Q verificationQuery](r *http.Request, fromString func([]byte) (Q, error)) *v1Result {
+	body, err := readBody(r)
 	if err != nil {
-		return results.withError(err)
+		return v1ResultNew().withError(err)
 	}
-	r.Body.Close()
 
 	// Create a query.
-	query, err := queryFromString(body)
+	query, err := fromString(body)
+	if err != nil {
+		return v1ResultNew().withError(err)
+	}
+
+	return verifyQueryV1(context.Background(), query)
+}
+
+// verifyQueryV1 returns the result of the verification of the query.
+func verifyQueryV1(ctx context.Context, query verificationQuery) *v1Result {
+	results := v1ResultNew()
+
+	result, err := validateAndVerify(ctx, query)
 	if err != nil {
 		return results.withError(err)
 	}
 
-	// Validate it.
+	if query.printProvenance() {
+		results = results.withIntotoStatement(result.Statement)
+	}
+
+	return results.withBuilderID(result.BuilderID.String()).withValidation(validationSuccess)
+}
+
+// validateAndVerify validates the query, then runs the verification.
+func validateAndVerify(ctx context.Context, query verificationQuery) (*utils.VerificationResult, error) {
 	if err := query.validate(); err != nil {
-		return results.withError(err)
+		return nil, err
 	}
+	return query.verify(ctx)
+}
 
-	// Run the verification.
+// verify verifies the validated query.
+func (q *v1Query) verify(ctx context.Context) (result *utils.VerificationResult, err error) {
 	provenanceOpts := &options.ProvenanceOpts{
-		ExpectedSourceURI:    query.Source,
-		ExpectedBranch:       query.Branch,
-		ExpectedDigest:       query.ArtifactHash,
-		ExpectedVersionedTag: query.VersionedTag,
-		ExpectedTag:          query.Tag,
+		ExpectedSourceURI:    q.Source,
+		ExpectedBranch:       q.Branch,
+		ExpectedDigest:       q.ArtifactHash,
+		ExpectedVersionedTag: q.VersionedTag,
+		ExpectedTag:          q.Tag,
 	}
 
 	builderOpts := &options.BuilderOpts{
-		ExpectedID: query.BuilderID,
+		ExpectedID: q.BuilderID,
 	}
 
-	ctx := context.Background()
-	p, builderID, err := verifiers.VerifyArtifact(ctx, []byte(query.DsseEnvelope),
-		query.ArtifactHash, provenanceOpts, builderOpts)
-	if err != nil {
-		return results.withError(err)
-	}
+	ctx, done := observeVerification(ctx, verifierLabel(builderOpts))
+	defer func() { done(err) }()
 
-	if query.PrintProvenance != nil && *query.PrintProvenance {
-		results = results.withIntotoStatement(p)
+	key, err := newCacheKey(kindArtifact, "", []byte(q.DsseEnvelope), q.ArtifactHash,
+		provenanceOpts, builderOpts)
+	if err != nil {
+		return nil, err
 	}
-
-	return results.withBuilderID(builderID.String()).withValidation(validationSuccess)
+	return cachedVerify(ctx, key, func() (*utils.VerificationResult, error) {
+		return verifiers.VerifyArtifact(ctx, []byte(q.DsseEnvelope),
+			q.ArtifactHash, provenanceOpts, builderOpts, nil)
+	})
 }
 
 func queryFromString(content []byte) (*v1Query, error) {
@@ -157,6 +207,10 @@ func queryFromString(content []byte) (*v1Query, error) {
 	return &query, nil
 }
 
+func (q *v1Query) printProvenance() bool {
+	return q.PrintProvenance != nil && *q.PrintProvenance
+}
+
 func (q *v1Query) validate() error {
 	if q.Source == "" {
 		return fmt.Errorf("%w: empty source", errInvalid)
@@ -1,5 +1,10 @@
 package options
 
+import (
+	"crypto"
+	"crypto/x509"
+)
+
 // ProvenanceOpts are the options for checking provenance information.
 type ProvenanceOpts struct {
 	// ExpectedBranch is the expected branch (github_ref or github_base_ref) in
@@ -34,3 +39,73 @@ type BuilderOpts struct {
 	// ExpectedID is the expected builder ID.
 	ExpectedID *string
 }
+
+// VerifierOpts are the options for the Sigstore instance used to verify
+// signatures. Empty fields default to the Sigstore public-good instance.
+type VerifierOpts struct {
+	// RekorURL is the address of the Rekor transparency log.
+	RekorURL string
+
+	// TrustedRoot is the content of a Sigstore trusted_root.json file with
+	// the verification material to use for this verification. If empty,
+	// the trusted root loaded by verifiers.LoadTrustedRoot, or fetched
+	// from TUF, is used.
+	TrustedRoot []byte
+
+	// FulcioRoots is the pool of trusted Fulcio root certificates.
+	// If nil, the roots of the trusted root are used.
+	FulcioRoots *x509.CertPool
+
+	// FulcioIntermediates is the pool of Fulcio intermediate certificates.
+	// If nil, the intermediates of the trusted root are used.
+	FulcioIntermediates *x509.CertPool
+
+	// CertOIDCIssuers are the accepted OIDC issuers of the signing certificates.
+	CertOIDCIssuers []string
+
+	// CertSubjectRegexps are the accepted patterns for the subject
+	// of the signing certificates.
+	CertSubjectRegexps []string
+
+	// RequireInclusionProof requires the transparency log entries of
+	// Sigstore bundles to include an inclusion proof and a signed checkpoint.
+	// Otherwise, they are verified when present, and the SignedEntryTimestamp
+	// is sufficient when they are not.
+	RequireInclusionProof bool
+
+	// MinTlogEntries is the number of entries from distinct transparency
+	// logs that must verify and match the content of Sigstore bundles.
+	// If zero, a single entry is required.
+	MinTlogEntries int
+
+	// TlogCacheDir is a directory in which to cache the verified Rekor
+	// entries. Cached entries are verified again, offline, when read.
+	// If empty, the entries are fetched from Rekor for each verification.
+	TlogCacheDir string
+
+	// NpmRegistryKeysPath is the path to a file containing the signing keys
+	// of the npm registry, in the format of https://registry.npmjs.org/-/npm/v1/keys.
+	// If empty, the keys embedded in the verifier are used.
+	NpmRegistryKeysPath string
+}
+
+// VSAOpts are the options for checking a verification summary attestation.
+type VSAOpts struct {
+	// VerifierKey is the public key of the trusted verifier
+	// that signed the VSA.
+	VerifierKey crypto.PublicKey
+
+	// ExpectedResourceURI is the expected resource URI of the artifact.
+	ExpectedResourceURI string
+
+	// ExpectedDigest is the expected sha256 digest of the artifact.
+	ExpectedDigest string
+
+	// ExpectedVerifierID is the expected ID of the verifier.
+	// It is not checked if empty.
+	ExpectedVerifierID string
+
+	// MinimumBuildLevel is the minimum SLSA build level the VSA
+	// must have verified.
+	MinimumBuildLevel int
+}
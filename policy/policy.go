@@ -0,0 +1,220 @@
+// Package policy loads verification policies. A policy maps artifacts
+// and images to the expected source, refs, builders and workflow inputs
+// of their provenance, so that they do not need to be passed on the command
+// line for each verification.
+//
+// Example policy:
+//
+//	version: 1
+//	artifacts:
+//	  - name: "slsa-verifier-linux-*"
+//	    source: github.com/slsa-framework/slsa-verifier
+//	    tags: ["v2.3.0"]
+//	    builders:
+//	      - https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_go_slsa3.yml
+//	  - image: ghcr.io/slsa-framework/example-package
+//	    source: github.com/slsa-framework/example-package
+//	    branches: ["main"]
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+// Version is the supported policy version.
+const Version = 1
+
+// Policy is a verification policy.
+type Policy struct {
+	// Version is the version of the policy format.
+	Version int `json:"version"`
+
+	// Artifacts are the rules for artifacts and images. The first
+	// rule matching an artifact is used.
+	Artifacts []Rule `json:"artifacts"`
+}
+
+// Rule contains the expectations for the artifacts or images it matches.
+type Rule struct {
+	// Name is a pattern matched against the base name of artifacts,
+	// using the syntax of path.Match.
+	Name string `json:"name,omitempty"`
+
+	// Image is the repository of the images matched by the rule,
+	// e.g. ghcr.io/org/repo.
+	Image string `json:"image,omitempty"`
+
+	// Source is the expected source repository, e.g. github.com/org/repo.
+	Source string `json:"source"`
+
+	// Branches are the allowed branches.
+	Branches []string `json:"branches,omitempty"`
+
+	// Tags are the allowed tags.
+	Tags []string `json:"tags,omitempty"`
+
+	// VersionedTag is the expected version, matched using semantic versioning.
+	VersionedTag string `json:"versionedTag,omitempty"`
+
+	// Builders are the trusted builder IDs. If empty, the default
+	// trusted builders of the verifiers are used.
+	Builders []string `json:"builders,omitempty"`
+
+	// WorkflowInputs are the required workflow inputs.
+	WorkflowInputs map[string]string `json:"workflowInputs,omitempty"`
+}
+
+// Expectation contains the options to verify an artifact with.
+type Expectation struct {
+	ProvenanceOpts *options.ProvenanceOpts
+	BuilderOpts    *options.BuilderOpts
+}
+
+// FromFile reads a policy from a YAML or JSON file.
+func FromFile(path string) (*Policy, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return FromBytes(content)
+}
+
+// FromBytes parses a YAML or JSON policy.
+func FromBytes(content []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.UnmarshalStrict(content, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidPolicy, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (p *Policy) validate() error {
+	if p.Version != Version {
+		return fmt.Errorf("%w: unsupported version %d", serrors.ErrorInvalidPolicy, p.Version)
+	}
+	for i := range p.Artifacts {
+		r := &p.Artifacts[i]
+		if (r.Name == "") == (r.Image == "") {
+			return fmt.Errorf("%w: rule %d: exactly one of name or image must be set",
+				serrors.ErrorInvalidPolicy, i)
+		}
+		if r.Name != "" {
+			if _, err := path.Match(r.Name, ""); err != nil {
+				return fmt.Errorf("%w: rule %d: %v", serrors.ErrorInvalidPolicy, i, err)
+			}
+		}
+		if r.Source == "" {
+			return fmt.Errorf("%w: rule %d: empty source", serrors.ErrorInvalidPolicy, i)
+		}
+		if r.VersionedTag != "" && len(r.Tags) > 0 {
+			return fmt.Errorf("%w: rule %d: tags and versionedTag are mutually exclusive",
+				serrors.ErrorInvalidPolicy, i)
+		}
+	}
+	return nil
+}
+
+// ArtifactExpectations returns the options to verify the artifact
+// at artifactPath with. The artifact is verified if it passes verification
+// with any of the returned options. The caller must set the expected digest.
+func (p *Policy) ArtifactExpectations(artifactPath string) ([]Expectation, error) {
+	name := path.Base(strings.ReplaceAll(artifactPath, "\\", "/"))
+	for i := range p.Artifacts {
+		r := &p.Artifacts[i]
+		if r.Name == "" {
+			continue
+		}
+		// The pattern was validated when the policy was loaded.
+		if ok, _ := path.Match(r.Name, name); ok {
+			return r.expectations(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: artifact '%s'", serrors.ErrorNoPolicyRule, artifactPath)
+}
+
+// ImageExpectations returns the options to verify the image with.
+// The image is verified if it passes verification with any of
+// the returned options. The caller must set the expected digest.
+func (p *Policy) ImageExpectations(image string) ([]Expectation, error) {
+	repository := imageRepository(image)
+	for i := range p.Artifacts {
+		r := &p.Artifacts[i]
+		if r.Image != "" && r.Image == repository {
+			return r.expectations(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: image '%s'", serrors.ErrorNoPolicyRule, image)
+}
+
+// imageRepository strips the digest and tag from an image reference.
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	// A colon after the last slash separates the tag.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// expectations returns one expectation per combination of allowed ref
+// and trusted builder.
+func (r *Rule) expectations() []Expectation {
+	type ref struct {
+		branch, tag *string
+	}
+	var refs []ref
+	for i := range r.Branches {
+		refs = append(refs, ref{branch: &r.Branches[i]})
+	}
+	for i := range r.Tags {
+		refs = append(refs, ref{tag: &r.Tags[i]})
+	}
+	if len(refs) == 0 {
+		refs = append(refs, ref{})
+	}
+
+	builders := []*string{nil}
+	if len(r.Builders) > 0 {
+		builders = nil
+		for i := range r.Builders {
+			builders = append(builders, &r.Builders[i])
+		}
+	}
+
+	var versionedTag *string
+	if r.VersionedTag != "" {
+		versionedTag = &r.VersionedTag
+	}
+
+	var expectations []Expectation
+	for _, ref := range refs {
+		for _, builder := range builders {
+			expectations = append(expectations, Expectation{
+				ProvenanceOpts: &options.ProvenanceOpts{
+					ExpectedSourceURI:      r.Source,
+					ExpectedBranch:         ref.branch,
+					ExpectedTag:            ref.tag,
+					ExpectedVersionedTag:   versionedTag,
+					ExpectedWorkflowInputs: r.WorkflowInputs,
+				},
+				BuilderOpts: &options.BuilderOpts{
+					ExpectedID: builder,
+				},
+			})
+		}
+	}
+	return expectations
+}
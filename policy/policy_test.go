@@ -0,0 +1,235 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+func Test_FromBytes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		content  string
+		expected error
+	}{
+		{
+			name: "valid yaml",
+			content: `
+version: 1
+artifacts:
+  - name: "binary-*"
+    source: github.com/org/repo
+    tags: ["v1.0.0"]
+  - image: ghcr.io/org/repo
+    source: github.com/org/repo
+    branches: ["main"]
+`,
+		},
+		{
+			name: "valid json",
+			content: `{"version": 1, "artifacts": [
+				{"name": "binary-*", "source": "github.com/org/repo", "versionedTag": "v1"}]}`,
+		},
+		{
+			name:     "invalid version",
+			content:  `version: 2`,
+			expected: serrors.ErrorInvalidPolicy,
+		},
+		{
+			name: "unknown field",
+			content: `
+version: 1
+artifacts:
+  - name: "binary-*"
+    source: github.com/org/repo
+    branch: main
+`,
+			expected: serrors.ErrorInvalidPolicy,
+		},
+		{
+			name: "name and image",
+			content: `
+version: 1
+artifacts:
+  - name: "binary-*"
+    image: ghcr.io/org/repo
+    source: github.com/org/repo
+`,
+			expected: serrors.ErrorInvalidPolicy,
+		},
+		{
+			name: "no name nor image",
+			content: `
+version: 1
+artifacts:
+  - source: github.com/org/repo
+`,
+			expected: serrors.ErrorInvalidPolicy,
+		},
+		{
+			name: "invalid pattern",
+			content: `
+version: 1
+artifacts:
+  - name: "binary-["
+    source: github.com/org/repo
+`,
+			expected: serrors.ErrorInvalidPolicy,
+		},
+		{
+			name: "empty source",
+			content: `
+version: 1
+artifacts:
+  - name: "binary-*"
+`,
+			expected: serrors.ErrorInvalidPolicy,
+		},
+		{
+			name: "tags and versioned tag",
+			content: `
+version: 1
+artifacts:
+  - name: "binary-*"
+    source: github.com/org/repo
+    tags: ["v1.0.0"]
+    versionedTag: v1
+`,
+			expected: serrors.ErrorInvalidPolicy,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := FromBytes([]byte(tt.content))
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func Test_Expectations(t *testing.T) {
+	t.Parallel()
+
+	content := `
+version: 1
+artifacts:
+  - name: "binary-linux-*"
+    source: github.com/org/repo
+    branches: ["main"]
+    tags: ["v1.0.0"]
+    builders:
+      - https://github.com/org/builder/.github/workflows/builder.yml
+    workflowInputs:
+      release: "true"
+  - name: "binary-*"
+    source: github.com/org/other
+    versionedTag: v1
+  - image: ghcr.io/org/repo
+    source: github.com/org/repo
+`
+	p, err := FromBytes([]byte(content))
+	if err != nil {
+		t.Fatalf("FromBytes: %v", err)
+	}
+
+	main := "main"
+	tag := "v1.0.0"
+	version := "v1"
+	builder := "https://github.com/org/builder/.github/workflows/builder.yml"
+	inputs := map[string]string{"release": "true"}
+
+	tests := []struct {
+		name     string
+		artifact string
+		image    string
+		want     []Expectation
+		expected error
+	}{
+		{
+			name:     "first matching rule",
+			artifact: "dist/binary-linux-amd64",
+			want: []Expectation{
+				{
+					ProvenanceOpts: &options.ProvenanceOpts{
+						ExpectedSourceURI:      "github.com/org/repo",
+						ExpectedBranch:         &main,
+						ExpectedWorkflowInputs: inputs,
+					},
+					BuilderOpts: &options.BuilderOpts{ExpectedID: &builder},
+				},
+				{
+					ProvenanceOpts: &options.ProvenanceOpts{
+						ExpectedSourceURI:      "github.com/org/repo",
+						ExpectedTag:            &tag,
+						ExpectedWorkflowInputs: inputs,
+					},
+					BuilderOpts: &options.BuilderOpts{ExpectedID: &builder},
+				},
+			},
+		},
+		{
+			name:     "second rule",
+			artifact: "binary-darwin-amd64",
+			want: []Expectation{
+				{
+					ProvenanceOpts: &options.ProvenanceOpts{
+						ExpectedSourceURI:    "github.com/org/other",
+						ExpectedVersionedTag: &version,
+					},
+					BuilderOpts: &options.BuilderOpts{},
+				},
+			},
+		},
+		{
+			name:     "no matching artifact rule",
+			artifact: "other",
+			expected: serrors.ErrorNoPolicyRule,
+		},
+		{
+			name:  "image with tag and digest",
+			image: "ghcr.io/org/repo:v1.0.0@sha256:4f0f32a4b7c2b1d4c1a6c55a1bd1b3e7b6b3f7f4b5c9c0d1e2f3a4b5c6d7e8f9",
+			want: []Expectation{
+				{
+					ProvenanceOpts: &options.ProvenanceOpts{
+						ExpectedSourceURI: "github.com/org/repo",
+					},
+					BuilderOpts: &options.BuilderOpts{},
+				},
+			},
+		},
+		{
+			name:     "no matching image rule",
+			image:    "ghcr.io/org/other@sha256:4f0f32a4b7c2b1d4c1a6c55a1bd1b3e7b6b3f7f4b5c9c0d1e2f3a4b5c6d7e8f9",
+			expected: serrors.ErrorNoPolicyRule,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []Expectation
+			var err error
+			if tt.image != "" {
+				got, err = p.ImageExpectations(tt.image)
+			} else {
+				got, err = p.ArtifactExpectations(tt.artifact)
+			}
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected expectations (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
-var SLSAVerifiers = make(map[string]SLSAVerifier)
+var (
+	SLSAVerifiers = make(map[string]SLSAVerifier)
+	VSAVerifiers  = make(map[string]VSAVerifier)
+)
 
 type SLSAVerifier interface {
 	// IsAuthoritativeFor checks whether a verifier can
@@ -20,22 +23,52 @@ type SLSAVerifier interface {
 		provenance []byte, artifactHash string,
 		provenanceOpts *options.ProvenanceOpts,
 		builderOpts *options.BuilderOpts,
-	) ([]byte, *utils.TrustedBuilderID, error)
+		verifierOpts *options.VerifierOpts,
+	) (*utils.VerificationResult, error)
+
+	// VerifyArtifacts verifies a provenance for several artifacts,
+	// verifying the signature only once. It returns one result
+	// per artifact hash.
+	VerifyArtifacts(ctx context.Context,
+		provenance []byte, artifactHashes []string,
+		provenanceOpts *options.ProvenanceOpts,
+		builderOpts *options.BuilderOpts,
+		verifierOpts *options.VerifierOpts,
+	) ([]utils.ArtifactResult, error)
 
 	// VerifyImage verifies a provenance for a supplied OCI image.
 	VerifyImage(ctx context.Context,
 		provenance []byte, artifactImage string,
 		provenanceOpts *options.ProvenanceOpts,
 		builderOpts *options.BuilderOpts,
-	) ([]byte, *utils.TrustedBuilderID, error)
+		verifierOpts *options.VerifierOpts,
+	) (*utils.VerificationResult, error)
 
 	VerifyNpmPackage(ctx context.Context,
 		attestations []byte, tarballHash string,
 		provenanceOpts *options.ProvenanceOpts,
 		builderOpts *options.BuilderOpts,
-	) ([]byte, *utils.TrustedBuilderID, error)
+		verifierOpts *options.VerifierOpts,
+	) (*utils.VerificationResult, error)
 }
 
 func RegisterVerifier(name string, verifier SLSAVerifier) {
 	SLSAVerifiers[name] = verifier
 }
+
+type VSAVerifier interface {
+	// IsAuthoritativeFor checks whether a verifier can
+	// verify VSAs with the given predicate type.
+	IsAuthoritativeFor(predicateType string) bool
+
+	// VerifyVSA verifies a verification summary attestation
+	// for a supplied artifact.
+	VerifyVSA(ctx context.Context,
+		attestation []byte,
+		vsaOpts *options.VSAOpts,
+	) (*utils.VerificationResult, error)
+}
+
+func RegisterVSAVerifier(name string, verifier VSAVerifier) {
+	VSAVerifiers[name] = verifier
+}
@@ -1,11 +1,11 @@
 package gcb
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
 	"regexp"
 	"strings"
@@ -16,6 +16,7 @@ import (
 	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
 
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gcb/keys"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
@@ -69,6 +70,7 @@ type Provenance struct {
 	gcloudProv              *gloudProvenance
 	verifiedProvenance      *provenance
 	verifiedIntotoStatement *v01IntotoStatement
+	verifiedKey             string
 }
 
 func ProvenanceFromBytes(payload []byte) (*Provenance, error) {
@@ -103,6 +105,40 @@ func (p *Provenance) GetVerifiedIntotoStatement() ([]byte, error) {
 	return d, nil
 }
 
+// GetVerifiedSigningKey returns the name of the region key that verified
+// the signature.
+func (p *Provenance) GetVerifiedSigningKey() (string, error) {
+	if err := p.isVerified(); err != nil {
+		return "", err
+	}
+	return p.verifiedKey, nil
+}
+
+// GetVerifiedSource returns the source URI and commit recorded in
+// the first material of the verified statement.
+func (p *Provenance) GetVerifiedSource() (string, string, error) {
+	if err := p.isVerified(); err != nil {
+		return "", "", err
+	}
+	materials := p.verifiedIntotoStatement.Predicate.Materials
+	if len(materials) == 0 {
+		return "", "", fmt.Errorf("%w: no materials", serrors.ErrorInvalidDssePayload)
+	}
+	uri := strings.TrimPrefix(materials[0].URI, "git+")
+	commit := materials[0].Digest["sha1"]
+	// In v0.2, the commit is part of the URI.
+	if commit == "" {
+		if i := strings.LastIndex(uri, "/commit/"); i >= 0 {
+			commit = uri[i+len("/commit/"):]
+			uri = uri[:i]
+		}
+	}
+	if i := strings.Index(uri, "#"); i >= 0 {
+		uri = uri[:i]
+	}
+	return uri, commit, nil
+}
+
 // VerifyMetadata verifies additional metadata contained in the provenance, which is not part
 // of the DSSE payload or headers. It is part of the payload returned by
 // `gcloud artifacts docker images describe image:tag --format json --show-provenance`.
@@ -125,8 +161,13 @@ func (p *Provenance) VerifyMetadata(provenanceOpts *options.ProvenanceOpts) erro
 	// The `ResourceURI` is container@sha256:hash, without the tag.
 	// We only verify the URI's sha256 for simplicity.
 	if !strings.HasSuffix(prov.ResourceURI, "@sha256:"+provenanceOpts.ExpectedDigest) {
-		return fmt.Errorf("%w: expected resourceUri '%s', got '%s'",
-			serrors.ErrorMismatchHash, provenanceOpts.ExpectedDigest, prov.ResourceURI)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchHash,
+			Check:    utils.CheckSubjectDigest,
+			Field:    "resourceUri",
+			Expected: provenanceOpts.ExpectedDigest,
+			Actual:   prov.ResourceURI,
+		}
 	}
 	return nil
 }
@@ -144,17 +185,25 @@ func (p *Provenance) VerifySummary(provenanceOpts *options.ProvenanceOpts) error
 
 	// Validate the digest.
 	if p.gcloudProv.ImageSummary.Digest != "sha256:"+provenanceOpts.ExpectedDigest {
-		return fmt.Errorf("%w: expected summary digest '%s', got '%s'",
-			serrors.ErrorMismatchHash, provenanceOpts.ExpectedDigest,
-			p.gcloudProv.ImageSummary.Digest)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchHash,
+			Check:    utils.CheckSubjectDigest,
+			Field:    "image_summary.digest",
+			Expected: "sha256:" + provenanceOpts.ExpectedDigest,
+			Actual:   p.gcloudProv.ImageSummary.Digest,
+		}
 	}
 
 	// Validate the qualified digest.
 	if !strings.HasSuffix(p.gcloudProv.ImageSummary.FullyQualifiedDigest,
 		"sha256:"+provenanceOpts.ExpectedDigest) {
-		return fmt.Errorf("%w: expected fully qualifiedd digest '%s', got '%s'",
-			serrors.ErrorMismatchHash, provenanceOpts.ExpectedDigest,
-			p.gcloudProv.ImageSummary.FullyQualifiedDigest)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchHash,
+			Check:    utils.CheckSubjectDigest,
+			Field:    "image_summary.fully_qualified_digest",
+			Expected: "sha256:" + provenanceOpts.ExpectedDigest,
+			Actual:   p.gcloudProv.ImageSummary.FullyQualifiedDigest,
+		}
 	}
 	return nil
 }
@@ -299,8 +348,13 @@ func (p *Provenance) VerifyBuilder(builderOpts *options.BuilderOpts) (*utils.Tru
 	}
 
 	if ts != expectedType {
-		return nil, fmt.Errorf("%w: expected '%s', got '%s'", serrors.ErrorMismatchBuilderID,
-			expectedType, ts)
+		return nil, &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchBuilderID,
+			Check:    utils.CheckBuilderID,
+			Field:    "recipe.arguments.@type",
+			Expected: expectedType,
+			Actual:   ts,
+		}
 	}
 
 	return provBuilderID, nil
@@ -325,6 +379,7 @@ func (p *Provenance) VerifySubjectDigest(expectedHash string) error {
 	}
 
 	statement := p.verifiedIntotoStatement
+	var hashes []string
 	for _, subject := range statement.StatementHeader.Subject {
 		digestSet := subject.Digest
 		hash, exists := digestSet["sha256"]
@@ -335,13 +390,20 @@ func (p *Provenance) VerifySubjectDigest(expectedHash string) error {
 		if hash == expectedHash {
 			return nil
 		}
+		hashes = append(hashes, hash)
 	}
 
-	return fmt.Errorf("expected hash '%s' not found: %w", expectedHash, serrors.ErrorMismatchHash)
+	return &serrors.VerificationError{
+		Err:      serrors.ErrorMismatchHash,
+		Check:    utils.CheckSubjectDigest,
+		Field:    "subject.digest.sha256",
+		Expected: expectedHash,
+		Actual:   strings.Join(hashes, ","),
+	}
 }
 
 // Verify source URI in provenance statement.
-func (p *Provenance) VerifySourceURI(expectedSourceURI string, builderID utils.TrustedBuilderID) error {
+func (p *Provenance) VerifySourceURI(ctx context.Context, expectedSourceURI string, builderID utils.TrustedBuilderID) error {
 	if err := p.isVerified(); err != nil {
 		return err
 	}
@@ -364,10 +426,12 @@ func (p *Provenance) VerifySourceURI(expectedSourceURI string, builderID utils.T
 
 	// The build was not configured with a GitHub trigger. Warn.
 	if strings.HasPrefix(uri, "gs://") {
-		fmt.Fprintf(os.Stderr, `This build was not configured with a GitHub trigger `+
-			`and will not match on an expected, version controlled source URI. `+
-			`See Cloud Build's documentation on building repositories from GitHub: `+
-			`https://cloud.google.com/build/docs/automating-builds/github/build-repos-from-github`)
+		events.Emit(ctx, events.Warning{
+			Message: `This build was not configured with a GitHub trigger ` +
+				`and will not match on an expected, version controlled source URI. ` +
+				`See Cloud Build's documentation on building repositories from GitHub: ` +
+				`https://cloud.google.com/build/docs/automating-builds/github/build-repos-from-github`,
+		})
 	}
 
 	var err error
@@ -378,8 +442,13 @@ func (p *Provenance) VerifySourceURI(expectedSourceURI string, builderID utils.T
 		// `https://github.com/laurentsimon/gcb-tests/commit/01ce393d04eb6df2a7b2b3e95d4126e687afb7ae`.
 		if !strings.HasPrefix(uri, expectedSourceURI+"/commit/") &&
 			!strings.HasPrefix(uri, expectedSourceURI+"#") {
-			return fmt.Errorf("%w: expected '%s', got '%s'",
-				serrors.ErrorMismatchSource, expectedSourceURI, uri)
+			return &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchSource,
+				Check:    utils.CheckSourceURI,
+				Field:    "materials.uri",
+				Expected: expectedSourceURI,
+				Actual:   uri,
+			}
 		}
 		// In v0.3, it uses the standard intoto and has the commit sha in its own
 		// `digest.sha1` field.
@@ -387,8 +456,13 @@ func (p *Provenance) VerifySourceURI(expectedSourceURI string, builderID utils.T
 		// The latter case is a versioned GCS source.
 		if uri != expectedSourceURI &&
 			!strings.HasPrefix(uri, expectedSourceURI+"#") {
-			return fmt.Errorf("%w: expected '%s', got '%s'",
-				serrors.ErrorMismatchSource, expectedSourceURI, uri)
+			return &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchSource,
+				Check:    utils.CheckSourceURI,
+				Field:    "materials.uri",
+				Expected: expectedSourceURI,
+				Actual:   uri,
+			}
 		}
 	default:
 		err = fmt.Errorf("%w: version '%s'",
@@ -409,8 +483,13 @@ func (p *Provenance) VerifyTag(expectedTag string) error {
 	}
 
 	if provenanceTag != expectedTag {
-		return fmt.Errorf("%w: expected '%s', got '%s'",
-			serrors.ErrorMismatchTag, expectedTag, provenanceTag)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchTag,
+			Check:    utils.CheckTag,
+			Field:    "substitutions.TAG_NAME",
+			Expected: expectedTag,
+			Actual:   provenanceTag,
+		}
 	}
 	return nil
 }
@@ -470,7 +549,7 @@ func getSubstitutionsField(statement *v01IntotoStatement, name string) (string,
 
 // verifySignatures iterates over all the signatures in the DSSE and verifies them.
 // It succeeds if one of them can be verified.
-func (p *Provenance) verifySignatures(prov *provenance) error {
+func (p *Provenance) verifySignatures(ctx context.Context, prov *provenance) error {
 	// Verify the envelope type. It should be an intoto type.
 	if prov.Envelope.PayloadType != intoto.PayloadType {
 		return fmt.Errorf("%w: expected payload type '%s', got %s",
@@ -540,7 +619,8 @@ func (p *Provenance) verifySignatures(prov *provenance) error {
 		}
 		p.verifiedIntotoStatement = &statement
 		p.verifiedProvenance = prov
-		fmt.Fprintf(os.Stderr, "Verification succeeded with region key '%s'\n", region)
+		p.verifiedKey = region
+		events.Emit(ctx, events.SignatureVerified{Signer: region})
 		return nil
 	}
 
@@ -548,7 +628,7 @@ func (p *Provenance) verifySignatures(prov *provenance) error {
 }
 
 // VerifySignature verifiers the signature for a provenance.
-func (p *Provenance) VerifySignature() error {
+func (p *Provenance) VerifySignature(ctx context.Context) error {
 	if len(p.gcloudProv.ProvenanceSummary.Provenance) == 0 {
 		return fmt.Errorf("%w: no provenance found", serrors.ErrorInvalidDssePayload)
 	}
@@ -556,7 +636,7 @@ func (p *Provenance) VerifySignature() error {
 	// Iterate over all provenances available.
 	var errs []error
 	for i := range p.gcloudProv.ProvenanceSummary.Provenance {
-		err := p.verifySignatures(&p.gcloudProv.ProvenanceSummary.Provenance[i])
+		err := p.verifySignatures(ctx, &p.gcloudProv.ProvenanceSummary.Provenance[i])
 		if err != nil {
 			errs = append(errs, err)
 			continue
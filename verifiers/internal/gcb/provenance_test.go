@@ -1,7 +1,9 @@
 package gcb
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -493,7 +495,7 @@ func Test_VerifySourceURI(t *testing.T) {
 			if err != nil {
 				panic(fmt.Errorf("BuilderIDNew: %w", err))
 			}
-			err = prov.VerifySourceURI(tt.source, *builderID)
+			err = prov.VerifySourceURI(context.Background(), tt.source, *builderID)
 			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
 				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
 			}
@@ -597,7 +599,7 @@ func Test_VerifySignature(t *testing.T) {
 			if err := setStatement(prov); err != nil {
 				panic(fmt.Errorf("setStatement: %w", err))
 			}
-			err = prov.VerifySignature()
+			err = prov.VerifySignature(context.Background())
 			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
 				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
 			}
@@ -648,6 +650,7 @@ func Test_VerifySubjectDigest(t *testing.T) {
 		path     string
 		hash     string
 		expected error
+		details  *serrors.VerificationError
 	}{
 		{
 			name: "valid gcb provenance",
@@ -659,6 +662,13 @@ func Test_VerifySubjectDigest(t *testing.T) {
 			path:     "./testdata/gcloud-container-github.json",
 			hash:     "0a033b002f89ed2b8ea733162497fb70f1a4049a7f8602d6a33682b4ad9921fd",
 			expected: serrors.ErrorMismatchHash,
+			details: &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchHash,
+				Check:    utils.CheckSubjectDigest,
+				Field:    "subject.digest.sha256",
+				Expected: "0a033b002f89ed2b8ea733162497fb70f1a4049a7f8602d6a33682b4ad9921fd",
+				Actual:   "1a033b002f89ed2b8ea733162497fb70f1a4049a7f8602d6a33682b4ad9921fd",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -684,6 +694,15 @@ func Test_VerifySubjectDigest(t *testing.T) {
 			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
 				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
 			}
+			if tt.details != nil {
+				var verr *serrors.VerificationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("expected a VerificationError, got %v", err)
+				}
+				if diff := cmp.Diff(*tt.details, *verr, cmpopts.EquateErrors()); diff != "" {
+					t.Errorf("unexpected details (-want +got): \n%s", diff)
+				}
+			}
 		})
 	}
 }
@@ -970,6 +989,65 @@ func Test_VerifyBranch(t *testing.T) {
 	}
 }
 
+func Test_GetVerifiedSource(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		path   string
+		uri    string
+		commit string
+	}{
+		{
+			name:   "v0.2 commit in uri",
+			path:   "./testdata/gcloud-container-github.json",
+			uri:    "https://github.com/laurentsimon/gcb-tests",
+			commit: "fbbb98765e85ad464302dc5977968104d36e455e",
+		},
+		{
+			name:   "v0.3 commit in digest",
+			path:   "./testdata/gcloud-container-github-v03.json",
+			uri:    "https://github.com/laurentsimon/gcb-tests",
+			commit: "01ce393d04eb6df2a7b2b3e95d4126e687afb7ae",
+		},
+		{
+			name: "gcs source",
+			path: "./testdata/gcloud-container-gcs.json",
+			uri:  "gs://damith-sds_cloudbuild/source/1665165360.279777-955d1904741e4bbeb3461080299e929a.tgz",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := os.ReadFile(tt.path)
+			if err != nil {
+				panic(fmt.Errorf("os.ReadFile: %w", err))
+			}
+
+			prov, err := ProvenanceFromBytes(content)
+			if err != nil {
+				panic(fmt.Errorf("ProvenanceFromBytes: %w", err))
+			}
+
+			if err := setStatement(prov); err != nil {
+				panic(fmt.Errorf("setStatement: %w", err))
+			}
+
+			uri, commit, err := prov.GetVerifiedSource()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if uri != tt.uri {
+				t.Errorf(cmp.Diff(uri, tt.uri))
+			}
+			if commit != tt.commit {
+				t.Errorf(cmp.Diff(commit, tt.commit))
+			}
+		})
+	}
+}
+
 func Test_getSubstitutionsField(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
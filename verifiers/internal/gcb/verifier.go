@@ -35,8 +35,19 @@ func (v *GCBVerifier) VerifyArtifact(ctx context.Context,
 	provenance []byte, artifactHash string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
-	return nil, nil, serrors.ErrorNotSupported
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	return nil, serrors.ErrorNotSupported
+}
+
+// VerifyArtifacts verifies provenance for several artifacts.
+func (v *GCBVerifier) VerifyArtifacts(ctx context.Context,
+	provenance []byte, artifactHashes []string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) ([]utils.ArtifactResult, error) {
+	return nil, serrors.ErrorNotSupported
 }
 
 // VerifyNpmPackage verifies an npm package tarball.
@@ -44,8 +55,9 @@ func (v *GCBVerifier) VerifyNpmPackage(ctx context.Context,
 	attestations []byte, tarballHash string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
-	return nil, nil, serrors.ErrorNotSupported
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	return nil, serrors.ErrorNotSupported
 }
 
 // VerifyImage verifies provenance for an OCI image.
@@ -53,81 +65,113 @@ func (v *GCBVerifier) VerifyImage(ctx context.Context,
 	provenance []byte, artifactImage string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
 	prov, err := ProvenanceFromBytes(provenance)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify signature on the intoto attestation.
-	if err := prov.VerifySignature(); err != nil {
-		return nil, nil, err
+	if err := prov.VerifySignature(ctx); err != nil {
+		return nil, err
 	}
 
 	// Verify intoto header.
 	if err := prov.VerifyIntotoHeaders(); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify the builder.
 	builderID, err := prov.VerifyBuilder(builderOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify subject digest.
 	if err := prov.VerifySubjectDigest(provenanceOpts.ExpectedDigest); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify source.
-	if err := prov.VerifySourceURI(provenanceOpts.ExpectedSourceURI, *builderID); err != nil {
-		return nil, nil, err
+	if err := prov.VerifySourceURI(ctx, provenanceOpts.ExpectedSourceURI, *builderID); err != nil {
+		return nil, err
 	}
 
 	// Verify metadata.
 	// This is metadata that GCB appends to the DSSE content.
 	if err := prov.VerifyMetadata(provenanceOpts); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify the summary.
 	// This is an additional structure that GCB prepends to the provenance.
 	if err := prov.VerifySummary(provenanceOpts); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify the text provenance.
 	// This is an additional structure that GCB prepends to the provenance,
 	// intended for humans. It reflect the DSSE payload.
 	if err := prov.VerifyTextProvenance(); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify branch.
 	if provenanceOpts.ExpectedBranch != nil {
 		if err := prov.VerifyBranch(*provenanceOpts.ExpectedBranch); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
 	// Verify the tag.
 	if provenanceOpts.ExpectedTag != nil {
 		if err := prov.VerifyTag(*provenanceOpts.ExpectedTag); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
 	// Verify the versioned tag.
 	if provenanceOpts.ExpectedVersionedTag != nil {
 		if err := prov.VerifyVersionedTag(*provenanceOpts.ExpectedVersionedTag); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
 	content, err := prov.GetVerifiedIntotoStatement()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	sourceURI, sourceCommit, err := prov.GetVerifiedSource()
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey, err := prov.GetVerifiedSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &utils.VerificationResult{
+		Statement:    content,
+		BuilderID:    builderID,
+		SourceURI:    sourceURI,
+		SourceCommit: sourceCommit,
+		SigningKey:   signingKey,
+		// Google Cloud Build meets the requirements of SLSA Build L3.
+		BuildLevel: 3,
+	}
+	result.AddChecks(utils.CheckSignature, utils.CheckIntotoHeaders,
+		utils.CheckBuilderID, utils.CheckSubjectDigest, utils.CheckSourceURI)
+	if provenanceOpts.ExpectedBranch != nil {
+		result.AddChecks(utils.CheckBranch)
+	}
+	if provenanceOpts.ExpectedTag != nil {
+		result.AddChecks(utils.CheckTag)
+	}
+	if provenanceOpts.ExpectedVersionedTag != nil {
+		result.AddChecks(utils.CheckVersionedTag)
 	}
-	return content, builderID, nil
+	return result, nil
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/sigstore/cosign/v2/pkg/cosign"
 	fulcio "github.com/sigstore/fulcio/pkg/certificate"
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
@@ -20,6 +21,8 @@ var (
 	httpsGithubCom           = "https://" + githubCom
 	// This is used in cosign's CheckOpts for validating the certificate. We
 	// do specific builder verification after this.
+	// Both values can be overridden with options.VerifierOpts for
+	// private Sigstore deployments.
 	certSubjectRegexp = httpsGithubCom + "*"
 )
 
@@ -43,6 +46,38 @@ var defaultBYOBReusableWorkflows = map[string]bool{
 	delegatorLowPermsGenericReusableWorkflow: true,
 }
 
+// certOIDCIssuers returns the OIDC issuers accepted for signing certificates.
+func certOIDCIssuers(verifierOpts *options.VerifierOpts) []string {
+	if verifierOpts == nil || len(verifierOpts.CertOIDCIssuers) == 0 {
+		return []string{certOidcIssuer}
+	}
+	return verifierOpts.CertOIDCIssuers
+}
+
+// certSubjectRegexps returns the patterns accepted for the subject
+// of signing certificates.
+func certSubjectRegexps(verifierOpts *options.VerifierOpts) []string {
+	if verifierOpts == nil || len(verifierOpts.CertSubjectRegexps) == 0 {
+		return []string{certSubjectRegexp}
+	}
+	return verifierOpts.CertSubjectRegexps
+}
+
+// CertIdentities returns the identities accepted by cosign when validating
+// signing certificates.
+func CertIdentities(verifierOpts *options.VerifierOpts) []cosign.Identity {
+	var identities []cosign.Identity
+	for _, issuer := range certOIDCIssuers(verifierOpts) {
+		for _, subject := range certSubjectRegexps(verifierOpts) {
+			identities = append(identities, cosign.Identity{
+				Issuer:        issuer,
+				SubjectRegExp: subject,
+			})
+		}
+	}
+	return identities
+}
+
 // VerifyCertficateSourceRepository verifies the source repository.
 func VerifyCertficateSourceRepository(id *WorkflowIdentity,
 	sourceRepo string,
@@ -52,8 +87,13 @@ func VerifyCertficateSourceRepository(id *WorkflowIdentity,
 	expectedSource := strings.TrimPrefix(sourceRepo, "git+https://")
 	expectedSource = strings.TrimPrefix(expectedSource, githubCom)
 	if id.SourceRepository != expectedSource {
-		return fmt.Errorf("%w: expected source '%s', got '%s'", serrors.ErrorMismatchSource,
-			expectedSource, id.SourceRepository)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchSource,
+			Check:    utils.CheckSourceURI,
+			Field:    "certificate source repository",
+			Expected: expectedSource,
+			Actual:   id.SourceRepository,
+		}
 	}
 	return nil
 }
@@ -65,10 +105,11 @@ func VerifyCertficateSourceRepository(id *WorkflowIdentity,
 func VerifyBuilderIdentity(id *WorkflowIdentity,
 	builderOpts *options.BuilderOpts,
 	defaultBuilders map[string]bool,
+	verifierOpts *options.VerifierOpts,
 ) (*utils.TrustedBuilderID, bool, error) {
 	// Issuer verification.
 	// NOTE: this is necessary before we do any further verification.
-	if id.Issuer != certOidcIssuer {
+	if !isTrustedIssuer(id.Issuer, verifierOpts) {
 		return nil, false, fmt.Errorf("%w: %s", serrors.ErrorInvalidOIDCIssuer, id.Issuer)
 	}
 
@@ -95,6 +136,15 @@ func VerifyBuilderIdentity(id *WorkflowIdentity,
 	return builderID, byob, nil
 }
 
+func isTrustedIssuer(issuer string, verifierOpts *options.VerifierOpts) bool {
+	for _, i := range certOIDCIssuers(verifierOpts) {
+		if issuer == i {
+			return true
+		}
+	}
+	return false
+}
+
 // Verifies the builder ID at path against an expected builderID.
 // If an expected builderID is not provided, uses the defaultBuilders.
 func verifyTrustedBuilderID(certPath, certTag string, expectedBuilderID *string, defaultTrustedBuilders map[string]bool) (*utils.TrustedBuilderID, bool, error) {
@@ -235,6 +285,36 @@ type WorkflowIdentity struct {
 	Issuer string
 }
 
+// result returns the identity in the form exposed by utils.VerificationResult.
+func (id *WorkflowIdentity) result() *utils.WorkflowIdentity {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	var hosted string
+	if id.SubjectHosted != nil {
+		switch *id.SubjectHosted {
+		case HostedGitHub:
+			hosted = "github-hosted"
+		case HostedSelf:
+			hosted = "self-hosted"
+		}
+	}
+	return &utils.WorkflowIdentity{
+		Issuer:             id.Issuer,
+		SourceRepository:   id.SourceRepository,
+		SourceSha1:         id.SourceSha1,
+		SourceRef:          deref(id.SourceRef),
+		SubjectWorkflowRef: id.SubjectWorkflowRef,
+		BuildTrigger:       id.BuildTrigger,
+		BuildConfigPath:    deref(id.BuildConfigPath),
+		RunID:              deref(id.RunID),
+		Hosted:             hosted,
+	}
+}
+
 func getHosted(cert *x509.Certificate) (*Hosted, error) {
 	runnerEnv, err := getExtension(cert, fulcio.OIDRunnerEnvironment, true)
 	if err != nil {
@@ -19,13 +19,14 @@ import (
 func Test_VerifyBuilderIdentity(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		name      string
-		workflow  *WorkflowIdentity
-		buildOpts *options.BuilderOpts
-		builderID string
-		defaults  map[string]bool
-		err       error
-		byob      bool
+		name         string
+		workflow     *WorkflowIdentity
+		buildOpts    *options.BuilderOpts
+		verifierOpts *options.VerifierOpts
+		builderID    string
+		defaults     map[string]bool
+		err          error
+		byob         bool
 	}{
 		{
 			name: "invalid job workflow ref",
@@ -75,6 +76,36 @@ func Test_VerifyBuilderIdentity(t *testing.T) {
 			defaults: defaultArtifactTrustedReusableWorkflows,
 			err:      serrors.ErrorInvalidOIDCIssuer,
 		},
+		{
+			name: "private cert issuer for general repos",
+			workflow: &WorkflowIdentity{
+				SourceRepository:   "asraa/slsa-on-github-test",
+				SourceSha1:         "0dfcd24824432c4ce587f79c918eef8fc2c44d7b",
+				SubjectWorkflowRef: trustedBuilderRepository + "/.github/workflows/builder_go_slsa3.yml@refs/tags/v1.2.3",
+				BuildTrigger:       "workflow_dispatch",
+				Issuer:             "https://oidc.example.com",
+			},
+			verifierOpts: &options.VerifierOpts{
+				CertOIDCIssuers: []string{"https://oidc.example.com"},
+			},
+			defaults:  defaultArtifactTrustedReusableWorkflows,
+			builderID: "https://github.com/" + trustedBuilderRepository + "/.github/workflows/builder_go_slsa3.yml@v1.2.3",
+		},
+		{
+			name: "public cert issuer with private issuers configured",
+			workflow: &WorkflowIdentity{
+				SourceRepository:   "asraa/slsa-on-github-test",
+				SourceSha1:         "0dfcd24824432c4ce587f79c918eef8fc2c44d7b",
+				SubjectWorkflowRef: trustedBuilderRepository + "/.github/workflows/builder_go_slsa3.yml@refs/tags/v1.2.3",
+				BuildTrigger:       "workflow_dispatch",
+				Issuer:             certOidcIssuer,
+			},
+			verifierOpts: &options.VerifierOpts{
+				CertOIDCIssuers: []string{"https://oidc.example.com"},
+			},
+			defaults: defaultArtifactTrustedReusableWorkflows,
+			err:      serrors.ErrorInvalidOIDCIssuer,
+		},
 		{
 			name: "valid trusted builder without tag",
 			workflow: &WorkflowIdentity{
@@ -302,7 +333,7 @@ func Test_VerifyBuilderIdentity(t *testing.T) {
 			if tt.builderID != "" {
 				opts.ExpectedID = &tt.builderID
 			}
-			id, byob, err := VerifyBuilderIdentity(tt.workflow, opts, tt.defaults)
+			id, byob, err := VerifyBuilderIdentity(tt.workflow, opts, tt.defaults, tt.verifierOpts)
 			if byob != tt.byob {
 				t.Errorf(cmp.Diff(byob, tt.byob))
 			}
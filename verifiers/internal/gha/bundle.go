@@ -9,11 +9,17 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	bundle_v1 "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"google.golang.org/protobuf/encoding/protojson"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
 )
 
 // Bundle specific errors.
@@ -21,28 +27,103 @@ var (
 	ErrorMismatchSignature       = errors.New("bundle tlog entry does not match signature")
 	ErrorUnexpectedEntryType     = errors.New("unexpected tlog entry type")
 	ErrorMissingCertInBundle     = errors.New("missing signing certificate in bundle")
+	ErrorMismatchCertInBundle    = errors.New("bundle tlog entry does not match signing certificate")
 	ErrorUnexpectedBundleContent = errors.New("expected DSSE bundle content")
+	ErrorUnsupportedBundle       = errors.New("unsupported bundle media type")
+)
+
+// The media types of the supported Sigstore bundle versions.
+const (
+	bundleV01MediaType = "application/vnd.dev.sigstore.bundle+json;version=0.1"
+	bundleV02MediaType = "application/vnd.dev.sigstore.bundle+json;version=0.2"
+	bundleV03MediaType = "application/vnd.dev.sigstore.bundle.v0.3+json"
+	// bundleV03LegacyMediaType is used by some v0.3 bundles.
+	bundleV03LegacyMediaType = "application/vnd.dev.sigstore.bundle+json;version=0.3"
 )
 
+// bundleMinorVersion returns the minor version of the bundle format.
+func bundleMinorVersion(bundle *bundle_v1.Bundle) (int, error) {
+	switch bundle.GetMediaType() {
+	case bundleV01MediaType:
+		return 1, nil
+	case bundleV02MediaType:
+		return 2, nil
+	case bundleV03MediaType, bundleV03LegacyMediaType:
+		return 3, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrorUnsupportedBundle, bundle.GetMediaType())
+	}
+}
+
 // IsSigstoreBundle checks if the provenance is a Sigstore bundle.
 func IsSigstoreBundle(bytes []byte) bool {
+	_, err := unmarshalBundle(bytes)
+	return err == nil
+}
+
+// unmarshalBundle parses a Sigstore bundle of any version.
+func unmarshalBundle(content []byte) (*bundle_v1.Bundle, error) {
+	content, err := bundleWithCertificateChain(content)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling bundle: %w", err)
+	}
 	var bundle bundle_v1.Bundle
-	if err := protojson.Unmarshal(bytes, &bundle); err != nil {
-		return false
+	if err := protojson.Unmarshal(content, &bundle); err != nil {
+		return nil, fmt.Errorf("unmarshaling bundle: %w", err)
 	}
-	return true
+	return &bundle, nil
+}
+
+// bundleWithCertificateChain replaces the single signing certificate of
+// v0.3 bundles with the equivalent certificate chain of earlier versions,
+// which is the only form supported by the protobuf definitions in use.
+func bundleWithCertificateChain(content []byte) ([]byte, error) {
+	var bundle map[string]json.RawMessage
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		return nil, err
+	}
+	var mediaType string
+	if err := json.Unmarshal(bundle["mediaType"], &mediaType); err != nil ||
+		(mediaType != bundleV03MediaType && mediaType != bundleV03LegacyMediaType) {
+		return content, nil
+	}
+
+	var material map[string]json.RawMessage
+	if err := json.Unmarshal(bundle["verificationMaterial"], &material); err != nil {
+		return content, nil
+	}
+	cert, ok := material["certificate"]
+	if !ok {
+		return content, nil
+	}
+	chain, err := json.Marshal(map[string][]json.RawMessage{
+		"certificates": {cert},
+	})
+	if err != nil {
+		return nil, err
+	}
+	delete(material, "certificate")
+	material["x509CertificateChain"] = chain
+	if bundle["verificationMaterial"], err = json.Marshal(material); err != nil {
+		return nil, err
+	}
+	return json.Marshal(bundle)
 }
 
 // verifyRekorEntryFromBundle extracts and verifies the Rekor entry from the Sigstore
-// bundle verification material, validating the SignedEntryTimestamp.
+// bundle verification material, validating the SignedEntryTimestamp and, if the
+// entry has one, the inclusion proof against its signed checkpoint.
+// If requireInclusionProof is true, the inclusion proof and checkpoint are required.
+// Entries without a SignedEntryTimestamp are verified by their inclusion proof only,
+// and their integrated time is not verified.
 func verifyRekorEntryFromBundle(ctx context.Context, tlogEntry *v1.TransparencyLogEntry,
-	trustedRoot *TrustedRoot) (
+	trustedRoot *TrustedRoot, requireInclusionProof bool) (
 	*models.LogEntryAnon, error,
 ) {
 	canonicalBody := tlogEntry.GetCanonicalizedBody()
 	logID := hex.EncodeToString(tlogEntry.GetLogId().GetKeyId())
 	rekorEntry := &models.LogEntryAnon{
-		Body:           canonicalBody,
+		Body:           base64.StdEncoding.EncodeToString(canonicalBody),
 		IntegratedTime: &tlogEntry.IntegratedTime,
 		LogIndex:       &tlogEntry.LogIndex,
 		LogID:          &logID,
@@ -51,8 +132,24 @@ func verifyRekorEntryFromBundle(ctx context.Context, tlogEntry *v1.TransparencyL
 		},
 	}
 
+	inclusionProof := tlogEntry.GetInclusionProof()
+	if requireInclusionProof &&
+		(inclusionProof == nil || inclusionProof.GetCheckpoint().GetEnvelope() == "") {
+		return nil, fmt.Errorf("%w: missing inclusion proof or checkpoint", serrors.ErrorInvalidRekorEntry)
+	}
+	if inclusionProof != nil {
+		rekorEntry.Verification.InclusionProof = inclusionProofFromBundle(inclusionProof)
+	}
+
+	if len(rekorEntry.Verification.SignedEntryTimestamp) == 0 {
+		if err := verifyTlogInclusion(ctx, *rekorEntry, trustedRoot.RekorPubKeys); err != nil {
+			return nil, err
+		}
+		return rekorEntry, nil
+	}
+
 	// Verify tlog entry.
-	if _, err := verifyTlogEntry(ctx, *rekorEntry, false,
+	if _, err := verifyTlogEntry(ctx, *rekorEntry, inclusionProof != nil,
 		trustedRoot.RekorPubKeys); err != nil {
 		return nil, err
 	}
@@ -60,6 +157,25 @@ func verifyRekorEntryFromBundle(ctx context.Context, tlogEntry *v1.TransparencyL
 	return rekorEntry, nil
 }
 
+// inclusionProofFromBundle converts the inclusion proof of a bundle
+// to the form returned by Rekor.
+func inclusionProofFromBundle(p *v1.InclusionProof) *models.InclusionProof {
+	hashes := make([]string, len(p.GetHashes()))
+	for i, h := range p.GetHashes() {
+		hashes[i] = hex.EncodeToString(h)
+	}
+	proof := &models.InclusionProof{
+		Hashes:   hashes,
+		LogIndex: swag.Int64(p.GetLogIndex()),
+		RootHash: swag.String(hex.EncodeToString(p.GetRootHash())),
+		TreeSize: swag.Int64(p.GetTreeSize()),
+	}
+	if checkpoint := p.GetCheckpoint().GetEnvelope(); checkpoint != "" {
+		proof.Checkpoint = swag.String(checkpoint)
+	}
+	return proof
+}
+
 // getEnvelopeFromBundle extracts the DSSE envelope from the Sigstore bundle.
 func getEnvelopeFromBundle(bundle *bundle_v1.Bundle) (*dsselib.Envelope, error) {
 	dsseEnvelope := bundle.GetDsseEnvelope()
@@ -80,11 +196,11 @@ func getEnvelopeFromBundle(bundle *bundle_v1.Bundle) (*dsselib.Envelope, error)
 }
 
 func getEnvelopeFromBundleBytes(content []byte) (*dsselib.Envelope, error) {
-	var bundle bundle_v1.Bundle
-	if err := protojson.Unmarshal(content, &bundle); err != nil {
-		return nil, fmt.Errorf("unmarshaling bundle: %w", err)
+	bundle, err := unmarshalBundle(content)
+	if err != nil {
+		return nil, err
 	}
-	env, err := getEnvelopeFromBundle(&bundle)
+	env, err := getEnvelopeFromBundle(bundle)
 	if err != nil {
 		return nil, err
 	}
@@ -107,11 +223,14 @@ func getLeafCertFromBundle(bundle *bundle_v1.Bundle) (*x509.Certificate, error)
 
 // matchRekorEntryWithEnvelope ensures that the log entry references the given
 // DSSE envelope. It MUST verify that the signatures match to ensure that the
-// tlog timestamp attests to the signature creation time.
-func matchRekorEntryWithEnvelope(tlogEntry *v1.TransparencyLogEntry, env *dsselib.Envelope) error {
+// tlog timestamp attests to the signature creation time. If cert is not nil,
+// it also verifies that the signatures were logged with the certificate.
+func matchRekorEntryWithEnvelope(tlogEntry *v1.TransparencyLogEntry, env *dsselib.Envelope,
+	cert *x509.Certificate,
+) error {
 	kindVersion := tlogEntry.GetKindVersion()
-	if kindVersion.Kind != "intoto" &&
-		kindVersion.Version != "0.0.2" {
+	if kindVersion.GetKind() != "intoto" ||
+		kindVersion.GetVersion() != "0.0.2" {
 		return fmt.Errorf("%w: expected intoto:0.0.2, got %s:%s", ErrorUnexpectedEntryType,
 			kindVersion.Kind, kindVersion.Version)
 	}
@@ -129,6 +248,9 @@ func matchRekorEntryWithEnvelope(tlogEntry *v1.TransparencyLogEntry, env *dsseli
 	if err := json.Unmarshal(specMarshal, &intotoObj); err != nil {
 		return fmt.Errorf("%w: %s", ErrorUnexpectedEntryType, err)
 	}
+	if intotoObj.Content == nil || intotoObj.Content.Envelope == nil {
+		return fmt.Errorf("%w: missing envelope", ErrorUnexpectedEntryType)
+	}
 
 	if len(env.Signatures) != len(intotoObj.Content.Envelope.Signatures) {
 		return fmt.Errorf("expected %d sigs in canonical body, got %d",
@@ -136,73 +258,89 @@ func matchRekorEntryWithEnvelope(tlogEntry *v1.TransparencyLogEntry, env *dsseli
 			len(intotoObj.Content.Envelope.Signatures))
 	}
 
-	// TODO(#487): verify the certs match.
 	for _, sig := range env.Signatures {
 		// The signature in the canonical body is double base64-encoded.
 		encodedEnvSig := base64.StdEncoding.EncodeToString(
 			[]byte(sig.Sig))
-		var matchCanonical bool
+		var matchCanonical *models.IntotoV002SchemaContentEnvelopeSignaturesItems0
 		for _, canonicalSig := range intotoObj.Content.Envelope.Signatures {
 			if canonicalSig.Sig.String() == encodedEnvSig {
-				matchCanonical = true
+				matchCanonical = canonicalSig
 			}
 		}
-		if !matchCanonical {
+		if matchCanonical == nil {
 			return ErrorMismatchSignature
 		}
+		if cert != nil {
+			if err := matchCertWithVerifier(cert, matchCanonical.PublicKey); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// matchCertWithVerifier ensures that the verifier of a signature in the log
+// entry, i.e. its PEM-encoded public key or certificate, is the certificate.
+func matchCertWithVerifier(cert *x509.Certificate, verifier *strfmt.Base64) error {
+	if verifier == nil {
+		return fmt.Errorf("%w: missing verifier", ErrorMismatchCertInBundle)
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(*verifier)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrorMismatchCertInBundle, err)
+	}
+	if len(certs) != 1 || !certs[0].Equal(cert) {
+		return ErrorMismatchCertInBundle
+	}
+	return nil
+}
+
 // VerifyProvenanceBundle verifies the DSSE envelope using the offline Rekor bundle and
 // returns the verified DSSE envelope containing the provenance
 // and the signing certificate given the provenance.
 func VerifyProvenanceBundle(ctx context.Context, bundleBytes []byte,
-	trustedRoot *TrustedRoot) (
+	trustedRoot *TrustedRoot, verifierOpts *options.VerifierOpts) (
 	*SignedAttestation, error,
 ) {
-	proposedSignedAtt, err := verifyBundleAndEntryFromBytes(ctx, bundleBytes, trustedRoot, true)
+	proposedSignedAtt, err := verifyBundleAndEntryFromBytes(ctx, bundleBytes, trustedRoot, true, verifierOpts)
 	if err != nil {
 		return nil, err
 	}
-	if err := verifySignedAttestation(proposedSignedAtt, trustedRoot); err != nil {
+	if err := verifySignedAttestation(proposedSignedAtt, trustedRoot, verifierOpts); err != nil {
 		return nil, err
 	}
 
 	return proposedSignedAtt, nil
 }
 
-// verifyBundleAndEntry validates the rekor entry inn the bundle
-// and that the entry (cert, signatures) matches the data in the bundle.
+// verifyBundleAndEntry validates the rekor entries in the bundle
+// and that they (cert, signatures) match the data in the bundle.
+// Entries that do not verify are skipped, so that bundles may carry
+// entries from logs that are not trusted. The bundle is accepted if
+// entries from verifierOpts.MinTlogEntries distinct logs verify.
+// The entries of v0.1 bundles require an inclusion promise, and those of later
+// versions an inclusion proof. The RFC 3161 timestamps of the bundle are verified.
 func verifyBundleAndEntry(ctx context.Context, bundle *bundle_v1.Bundle,
-	trustedRoot *TrustedRoot, requireCert bool,
+	trustedRoot *TrustedRoot, requireCert bool, verifierOpts *options.VerifierOpts,
 ) (*SignedAttestation, error) {
-	// We only expect one TLOG entry. If this changes in the future, we must iterate
-	// for a matching one.
-	if bundle.GetVerificationMaterial() == nil ||
-		len(bundle.GetVerificationMaterial().GetTlogEntries()) == 0 {
-		return nil, fmt.Errorf("bundle missing offline tlog verification material %d", len(bundle.GetVerificationMaterial().GetTlogEntries()))
-	}
-
-	// Verify tlog entry.
-	tlogEntry := bundle.GetVerificationMaterial().GetTlogEntries()[0]
-	rekorEntry, err := verifyRekorEntryFromBundle(ctx, tlogEntry, trustedRoot)
+	version, err := bundleMinorVersion(bundle)
 	if err != nil {
 		return nil, err
 	}
 
+	tlogEntries := bundle.GetVerificationMaterial().GetTlogEntries()
+	if len(tlogEntries) == 0 {
+		return nil, fmt.Errorf("bundle missing offline tlog verification material %d", len(tlogEntries))
+	}
+
 	// Extract DSSE envelope.
 	env, err := getEnvelopeFromBundle(bundle)
 	if err != nil {
 		return nil, err
 	}
 
-	// Match tlog entry signature with the envelope.
-	if err := matchRekorEntryWithEnvelope(tlogEntry, env); err != nil {
-		return nil, fmt.Errorf("matching bundle entry with content: %w", err)
-	}
-
 	// Get certificate from bundle.
 	var cert *x509.Certificate
 	if requireCert {
@@ -212,24 +350,90 @@ func verifyBundleAndEntry(ctx context.Context, bundle *bundle_v1.Bundle,
 		}
 	}
 
+	requireInclusionProof := version >= 2
+	minEntries := 1
+	if verifierOpts != nil {
+		requireInclusionProof = requireInclusionProof || verifierOpts.RequireInclusionProof
+		if verifierOpts.MinTlogEntries > 1 {
+			minEntries = verifierOpts.MinTlogEntries
+		}
+	}
+
+	// Verify the tlog entries, and keep the first valid one.
+	var rekorEntry *models.LogEntryAnon
+	var firstErr error
+	logIDs := make(map[string]bool)
+	for _, tlogEntry := range tlogEntries {
+		if version == 1 && tlogEntry.GetInclusionPromise() == nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%w: missing inclusion promise", serrors.ErrorInvalidRekorEntry)
+			}
+			continue
+		}
+		entry, err := verifyBundleTlogEntry(ctx, tlogEntry, env, cert,
+			trustedRoot, requireInclusionProof)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if rekorEntry == nil {
+			rekorEntry = entry
+		}
+		logIDs[*entry.LogID] = true
+	}
+	if rekorEntry == nil {
+		return nil, firstErr
+	}
+	if len(logIDs) < minEntries {
+		return nil, fmt.Errorf("%w: %d verified tlog entries from distinct logs, %d required",
+			serrors.ErrorInvalidRekorEntry, len(logIDs), minEntries)
+	}
+
+	timestamps, err := verifyBundleTimestamps(bundle, rekorEntry, trustedRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	return &SignedAttestation{
 		SigningCert: cert,
 		Envelope:    env,
 		RekorEntry:  rekorEntry,
+		Timestamps:  timestamps,
 	}, nil
 }
 
+// verifyBundleTlogEntry verifies a tlog entry of a bundle and that it
+// matches the envelope and the certificate.
+func verifyBundleTlogEntry(ctx context.Context, tlogEntry *v1.TransparencyLogEntry,
+	env *dsselib.Envelope, cert *x509.Certificate, trustedRoot *TrustedRoot,
+	requireInclusionProof bool,
+) (*models.LogEntryAnon, error) {
+	rekorEntry, err := verifyRekorEntryFromBundle(ctx, tlogEntry, trustedRoot,
+		requireInclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	// Match tlog entry signature and certificate with the envelope.
+	if err := matchRekorEntryWithEnvelope(tlogEntry, env, cert); err != nil {
+		return nil, fmt.Errorf("matching bundle entry with content: %w", err)
+	}
+	return rekorEntry, nil
+}
+
 // verifyBundleAndEntryFromBytes validates the rekor entry inn the bundle
 // and that the entry (cert, signatures) matches the data in the bundle.
 func verifyBundleAndEntryFromBytes(ctx context.Context, bundleBytes []byte,
-	trustedRoot *TrustedRoot, requireCert bool,
+	trustedRoot *TrustedRoot, requireCert bool, verifierOpts *options.VerifierOpts,
 ) (*SignedAttestation, error) {
 	// Extract the SigningCert, Envelope, and RekorEntry from the bundle.
-	var bundle bundle_v1.Bundle
-	if err := protojson.Unmarshal(bundleBytes, &bundle); err != nil {
-		return nil, fmt.Errorf("unmarshaling bundle: %w", err)
+	bundle, err := unmarshalBundle(bundleBytes)
+	if err != nil {
+		return nil, err
 	}
 
-	return verifyBundleAndEntry(ctx, &bundle,
-		trustedRoot, requireCert)
+	return verifyBundleAndEntry(ctx, bundle,
+		trustedRoot, requireCert, verifierOpts)
 }
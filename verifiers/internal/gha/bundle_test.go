@@ -1,13 +1,37 @@
 package gha
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	bundle_v1 "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	common_v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/util"
+	"github.com/sigstore/sigstore/pkg/signature"
+	sigopts "github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/sigstore/sigstore/pkg/tuf"
+
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
 )
 
 func Test_verifyBundle(t *testing.T) {
@@ -32,6 +56,11 @@ func Test_verifyBundle(t *testing.T) {
 			path:     "./testdata/bundle/mismatch-tlog.intoto.sigstore",
 			expected: ErrorMismatchSignature,
 		},
+		{
+			name:     "mismatch certificate",
+			path:     "./testdata/bundle/mismatch-cert.intoto.sigstore",
+			expected: ErrorMismatchCertInBundle,
+		},
 
 		{
 			name:     "invalid Rekor SET",
@@ -67,11 +96,515 @@ func Test_verifyBundle(t *testing.T) {
 				panic(fmt.Errorf("os.ReadFile: %w", err))
 			}
 
-			_, err = VerifyProvenanceBundle(ctx, content, trustedRoot)
+			_, err = VerifyProvenanceBundle(ctx, content, trustedRoot, nil)
+
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+		})
+	}
+}
+
+// testLog signs entries and checkpoints as a Rekor log.
+type testLog struct {
+	signer signature.SignerVerifier
+	logID  string
+}
+
+func newTestLog(t *testing.T) *testLog {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID, err := cosign.GetTransparencyLogID(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testLog{signer: signer, logID: logID}
+}
+
+func (l *testLog) trustedRoot(t *testing.T) *TrustedRoot {
+	t.Helper()
+	pubKey, err := l.signer.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &TrustedRoot{
+		RekorPubKeys: &cosign.TrustedTransparencyLogPubKeys{
+			Keys: map[string]cosign.TransparencyLogPubKey{
+				l.logID: {PubKey: pubKey, Status: tuf.Active},
+			},
+		},
+	}
+}
+
+func rfc6962Hash(prefix byte, b ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{prefix})
+	for _, p := range b {
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
+
+// entry returns a tlog entry for the body, with an inclusion proof in a
+// tree of two leaves and a checkpoint of the tree.
+func (l *testLog) entry(t *testing.T, body []byte) *v1.TransparencyLogEntry {
+	t.Helper()
+	logID, err := hex.DecodeString(l.logID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var integratedTime, logIndex int64 = 1680000000, 42
+
+	// The SignedEntryTimestamp signs the canonical JSON of the entry.
+	payload, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{base64.StdEncoding.EncodeToString(body), integratedTime, l.logID, logIndex})
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := l.signer.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sibling := rfc6962Hash(0, []byte("other entry"))
+	rootHash := rfc6962Hash(1, rfc6962Hash(0, body), sibling)
+	checkpoint, err := util.CreateSignedCheckpoint(util.Checkpoint{
+		Origin: "rekor.example.com - 1",
+		Size:   2,
+		Hash:   rootHash,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := checkpoint.Sign("rekor.example.com", l.signer, sigopts.WithContext(context.Background())); err != nil {
+		t.Fatal(err)
+	}
+	checkpointText, err := checkpoint.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &v1.TransparencyLogEntry{
+		LogIndex:          logIndex,
+		LogId:             &common_v1.LogId{KeyId: logID},
+		IntegratedTime:    integratedTime,
+		InclusionPromise:  &v1.InclusionPromise{SignedEntryTimestamp: set},
+		CanonicalizedBody: body,
+		InclusionProof: &v1.InclusionProof{
+			LogIndex:   0,
+			RootHash:   rootHash,
+			TreeSize:   2,
+			Hashes:     [][]byte{sibling},
+			Checkpoint: &v1.Checkpoint{Envelope: string(checkpointText)},
+		},
+	}
+}
+
+func Test_verifyRekorEntryFromBundle(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	log := newTestLog(t)
+	trustedRoot := log.trustedRoot(t)
+	body := []byte(`{"apiVersion":"0.0.2","kind":"intoto"}`)
+
+	tests := []struct {
+		name                  string
+		entry                 func() *v1.TransparencyLogEntry
+		requireInclusionProof bool
+		expected              error
+	}{
+		{
+			name:                  "valid inclusion proof",
+			entry:                 func() *v1.TransparencyLogEntry { return log.entry(t, body) },
+			requireInclusionProof: true,
+		},
+		{
+			name: "no inclusion proof",
+			entry: func() *v1.TransparencyLogEntry {
+				e := log.entry(t, body)
+				e.InclusionProof = nil
+				return e
+			},
+		},
+		{
+			name: "no inclusion proof in strict mode",
+			entry: func() *v1.TransparencyLogEntry {
+				e := log.entry(t, body)
+				e.InclusionProof = nil
+				return e
+			},
+			requireInclusionProof: true,
+			expected:              serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name: "no checkpoint",
+			entry: func() *v1.TransparencyLogEntry {
+				e := log.entry(t, body)
+				e.InclusionProof.Checkpoint = nil
+				return e
+			},
+		},
+		{
+			name: "no checkpoint in strict mode",
+			entry: func() *v1.TransparencyLogEntry {
+				e := log.entry(t, body)
+				e.InclusionProof.Checkpoint = nil
+				return e
+			},
+			requireInclusionProof: true,
+			expected:              serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name: "invalid inclusion proof",
+			entry: func() *v1.TransparencyLogEntry {
+				e := log.entry(t, body)
+				e.InclusionProof.Hashes = [][]byte{rfc6962Hash(0, []byte("wrong entry"))}
+				return e
+			},
+			expected: serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name: "checkpoint of another tree",
+			entry: func() *v1.TransparencyLogEntry {
+				e := log.entry(t, body)
+				e.InclusionProof.Checkpoint = log.entry(t, []byte("other body")).InclusionProof.Checkpoint
+				return e
+			},
+			expected: serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name: "checkpoint signed by another log",
+			entry: func() *v1.TransparencyLogEntry {
+				e := log.entry(t, body)
+				e.InclusionProof.Checkpoint = newTestLog(t).entry(t, body).InclusionProof.Checkpoint
+				return e
+			},
+			expected: serrors.ErrorInvalidRekorEntry,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := verifyRekorEntryFromBundle(ctx, tt.entry(), trustedRoot, tt.requireInclusionProof)
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+		})
+	}
+}
+
+// testBundle returns a bundle with a DSSE envelope logged by each of the logs.
+func testBundle(t *testing.T, logs ...*testLog) *bundle_v1.Bundle {
+	t.Helper()
+	envelope := &dsse.Envelope{
+		Payload:     []byte(`{"_type":"https://in-toto.io/Statement/v0.1"}`),
+		PayloadType: "application/vnd.in-toto+json",
+		Signatures:  []*dsse.Signature{{Sig: []byte("signature")}},
+	}
+
+	// The signature in the canonical body is double base64-encoded.
+	sig := strfmt.Base64(base64.StdEncoding.EncodeToString(envelope.Signatures[0].Sig))
+	publicKey := strfmt.Base64("public key")
+	body, err := json.Marshal(models.Intoto{
+		APIVersion: swag.String("0.0.2"),
+		Spec: models.IntotoV002Schema{
+			Content: &models.IntotoV002SchemaContent{
+				Envelope: &models.IntotoV002SchemaContentEnvelope{
+					Payload:     strfmt.Base64(envelope.Payload),
+					PayloadType: swag.String(envelope.PayloadType),
+					Signatures: []*models.IntotoV002SchemaContentEnvelopeSignaturesItems0{
+						{Sig: &sig, PublicKey: &publicKey},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
 
+	b := &bundle_v1.Bundle{
+		MediaType:            bundleV01MediaType,
+		VerificationMaterial: &bundle_v1.VerificationMaterial{},
+		Content:              &bundle_v1.Bundle_DsseEnvelope{DsseEnvelope: envelope},
+	}
+	for _, l := range logs {
+		entry := l.entry(t, body)
+		entry.KindVersion = &v1.KindVersion{Kind: "intoto", Version: "0.0.2"}
+		b.VerificationMaterial.TlogEntries = append(b.VerificationMaterial.TlogEntries, entry)
+	}
+	return b
+}
+
+// otherEntry returns an intoto entry of the log for another envelope.
+func otherEntry(t *testing.T, l *testLog) *v1.TransparencyLogEntry {
+	t.Helper()
+	entry := l.entry(t, []byte(`{"apiVersion":"0.0.2","kind":"intoto","spec":{}}`))
+	entry.KindVersion = &v1.KindVersion{Kind: "intoto", Version: "0.0.2"}
+	return entry
+}
+
+func Test_verifyBundleAndEntry_tlogEntries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	log1, log2, untrustedLog := newTestLog(t), newTestLog(t), newTestLog(t)
+	tsa := newTestTSA(t)
+	trustedRoot := log1.trustedRoot(t)
+	trustedRoot.RekorPubKeys.Keys[log2.logID] = log2.trustedRoot(t).RekorPubKeys.Keys[log2.logID]
+	trustedRoot.TimestampAuthorities = []TimestampAuthority{tsa.authority()}
+	withTimestamp := func(b *bundle_v1.Bundle, response []byte) *bundle_v1.Bundle {
+		b.VerificationMaterial.TimestampVerificationData = &bundle_v1.TimestampVerificationData{
+			Rfc3161Timestamps: []*common_v1.RFC3161SignedTimestamp{{SignedTimestamp: response}},
+		}
+		return b
+	}
+
+	tests := []struct {
+		name           string
+		bundle         func() *bundle_v1.Bundle
+		minTlogEntries int
+		noTSA          bool
+		expected       error
+	}{
+		{
+			name:   "single entry",
+			bundle: func() *bundle_v1.Bundle { return testBundle(t, log1) },
+		},
+		{
+			name:   "untrusted log first",
+			bundle: func() *bundle_v1.Bundle { return testBundle(t, untrustedLog, log1) },
+		},
+		{
+			name: "mismatched entry first",
+			bundle: func() *bundle_v1.Bundle {
+				b := testBundle(t, log1)
+				b.VerificationMaterial.TlogEntries = append(
+					[]*v1.TransparencyLogEntry{otherEntry(t, log1)},
+					b.VerificationMaterial.TlogEntries...)
+				return b
+			},
+		},
+		{
+			name: "mismatched entry only",
+			bundle: func() *bundle_v1.Bundle {
+				b := testBundle(t, log1)
+				b.VerificationMaterial.TlogEntries = []*v1.TransparencyLogEntry{otherEntry(t, log1)}
+				return b
+			},
+			expected: ErrorUnexpectedEntryType,
+		},
+		{
+			name:     "untrusted log only",
+			bundle:   func() *bundle_v1.Bundle { return testBundle(t, untrustedLog) },
+			expected: serrors.ErrorRekorPubKey,
+		},
+		{
+			name:           "distinct logs",
+			bundle:         func() *bundle_v1.Bundle { return testBundle(t, log1, untrustedLog, log2) },
+			minTlogEntries: 2,
+		},
+		{
+			name:           "same log twice",
+			bundle:         func() *bundle_v1.Bundle { return testBundle(t, log1, log1) },
+			minTlogEntries: 2,
+			expected:       serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name:           "untrusted second log",
+			bundle:         func() *bundle_v1.Bundle { return testBundle(t, log1, untrustedLog) },
+			minTlogEntries: 2,
+			expected:       serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name: "v0.2 entry without promise",
+			bundle: func() *bundle_v1.Bundle {
+				b := testBundle(t, log1)
+				b.MediaType = bundleV02MediaType
+				b.VerificationMaterial.TlogEntries[0].InclusionPromise = nil
+				return b
+			},
+		},
+		{
+			name: "v0.2 entry without inclusion proof",
+			bundle: func() *bundle_v1.Bundle {
+				b := testBundle(t, log1)
+				b.MediaType = bundleV02MediaType
+				b.VerificationMaterial.TlogEntries[0].InclusionProof = nil
+				return b
+			},
+			expected: serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name: "v0.1 entry without promise",
+			bundle: func() *bundle_v1.Bundle {
+				b := testBundle(t, log1)
+				b.VerificationMaterial.TlogEntries[0].InclusionPromise = nil
+				return b
+			},
+			expected: serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name: "unsupported media type",
+			bundle: func() *bundle_v1.Bundle {
+				b := testBundle(t, log1)
+				b.MediaType = "application/vnd.dev.sigstore.bundle+json;version=0.4"
+				return b
+			},
+			expected: ErrorUnsupportedBundle,
+		},
+		{
+			name: "valid timestamp",
+			bundle: func() *bundle_v1.Bundle {
+				return withTimestamp(testBundle(t, log1), tsa.timestamp(t, []byte("signature"), time.Now()))
+			},
+		},
+		{
+			name: "invalid timestamp",
+			bundle: func() *bundle_v1.Bundle {
+				return withTimestamp(testBundle(t, log1), tsa.timestamp(t, []byte("other signature"), time.Now()))
+			},
+			expected: serrors.ErrorInvalidTimestamp,
+		},
+		{
+			name: "timestamp without trusted authority",
+			bundle: func() *bundle_v1.Bundle {
+				return withTimestamp(testBundle(t, log1), tsa.timestamp(t, []byte("signature"), time.Now()))
+			},
+			noTSA: true,
+		},
+		{
+			name: "timestamp without trusted authority or promise",
+			bundle: func() *bundle_v1.Bundle {
+				b := testBundle(t, log1)
+				b.MediaType = bundleV02MediaType
+				b.VerificationMaterial.TlogEntries[0].InclusionPromise = nil
+				return withTimestamp(b, tsa.timestamp(t, []byte("signature"), time.Now()))
+			},
+			noTSA:    true,
+			expected: serrors.ErrorInvalidTimestamp,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := trustedRoot
+			if tt.noTSA {
+				r := *trustedRoot
+				r.TimestampAuthorities = nil
+				root = &r
+			}
+			_, err := verifyBundleAndEntry(ctx, tt.bundle(), root, false,
+				&options.VerifierOpts{MinTlogEntries: tt.minTlogEntries})
 			if !errCmp(err, tt.expected) {
 				t.Errorf(cmp.Diff(err, tt.expected))
 			}
 		})
 	}
 }
+
+func Test_unmarshalBundle(t *testing.T) {
+	t.Parallel()
+	content, err := os.ReadFile("./testdata/bundle/valid.intoto.sigstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bundle map[string]interface{}
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		t.Fatal(err)
+	}
+	material := bundle["verificationMaterial"].(map[string]interface{})
+	chain := material["x509CertificateChain"].(map[string]interface{})
+	cert := chain["certificates"].([]interface{})[0]
+
+	// withCertificate returns the bundle with a single certificate, as in v0.3.
+	withCertificate := func(mediaType string) []byte {
+		material := map[string]interface{}{
+			"certificate": cert,
+			"tlogEntries": material["tlogEntries"],
+		}
+		b, err := json.Marshal(map[string]interface{}{
+			"mediaType":            mediaType,
+			"verificationMaterial": material,
+			"dsseEnvelope":         bundle["dsseEnvelope"],
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name    string
+		content []byte
+		err     bool
+		version int
+	}{
+		{
+			name:    "v0.1",
+			content: content,
+			version: 1,
+		},
+		{
+			name:    "v0.3",
+			content: withCertificate(bundleV03MediaType),
+			version: 3,
+		},
+		{
+			name:    "v0.3 legacy media type",
+			content: withCertificate(bundleV03LegacyMediaType),
+			version: 3,
+		},
+		{
+			name:    "certificate in v0.2",
+			content: withCertificate(bundleV02MediaType),
+			err:     true,
+		},
+		{
+			name:    "not a bundle",
+			content: []byte(`{"payloadType": "application/vnd.in-toto+json"}`),
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := unmarshalBundle(tt.content)
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if IsSigstoreBundle(tt.content) == tt.err {
+				t.Errorf("unexpected IsSigstoreBundle: %v", !tt.err)
+			}
+			if err != nil {
+				return
+			}
+			version, err := bundleMinorVersion(b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != tt.version {
+				t.Errorf("unexpected version: %d", version)
+			}
+			if _, err := getLeafCertFromBundle(b); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -2,8 +2,6 @@ package gha
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
@@ -15,8 +13,10 @@ import (
 	intoto "github.com/in-toto/in-toto-golang/in_toto"
 	"github.com/secure-systems-lab/go-securesystemslib/dsse"
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance/common"
+	slsav02 "github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance/v0.2"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
@@ -36,24 +36,6 @@ const (
 
 var errrorInvalidAttestations = errors.New("invalid npm attestations")
 
-/*
-NOTE: key available at https://registry.npmjs.org/-/npm/v1/keys
-
-			https://docs.npmjs.com/about-registry-signatures
-		{
-		"keys": [
-		{
-			"expires": null,
-			"keyid": "SHA256:jl3bwswu80PjjokCgh0o2w5c2U4LhQAE57gj9cz1kzA",
-			"keytype": "ecdsa-sha2-nistp256",
-			"scheme": "ecdsa-sha2-nistp256",
-			"key": "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE1Olb3zMAFFxXKHiIkQO5cJ3Yhl5i6UPp+IhuteBJbuHcA5UogKo0EWtlWwW6KSaKoTNEYL7JlCQiVnkhBktUgg=="
-		}
-		]
-	}
-*/
-var npmRegistryPublicKey = "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE1Olb3zMAFFxXKHiIkQO5cJ3Yhl5i6UPp+IhuteBJbuHcA5UogKo0EWtlWwW6KSaKoTNEYL7JlCQiVnkhBktUgg=="
-
 type attestationSet struct {
 	Attestations []attestation `json:"attestations"`
 }
@@ -73,6 +55,7 @@ func (b *BundleBytes) UnmarshalJSON(data []byte) error {
 type Npm struct {
 	ctx                   context.Context
 	root                  *TrustedRoot
+	verifierOpts          *options.VerifierOpts
 	verifiedProvenanceAtt *SignedAttestation
 	verifiedPublishAtt    *SignedAttestation
 	provenanceAttestation *attestation
@@ -87,7 +70,9 @@ func (n *Npm) ProvenanceLeafCertificate() *x509.Certificate {
 	return n.verifiedProvenanceAtt.SigningCert
 }
 
-func NpmNew(ctx context.Context, root *TrustedRoot, attestationBytes []byte) (*Npm, error) {
+func NpmNew(ctx context.Context, root *TrustedRoot, attestationBytes []byte,
+	verifierOpts *options.VerifierOpts,
+) (*Npm, error) {
 	var aSet attestationSet
 	if err := json.Unmarshal(attestationBytes, &aSet); err != nil {
 		return nil, fmt.Errorf("%w: json.Unmarshal: %v", errrorInvalidAttestations, err)
@@ -100,6 +85,7 @@ func NpmNew(ctx context.Context, root *TrustedRoot, attestationBytes []byte) (*N
 	return &Npm{
 		ctx:                   ctx,
 		root:                  root,
+		verifierOpts:          verifierOpts,
 		provenanceAttestation: prov,
 		publishAttestation:    pub,
 	}, nil
@@ -132,7 +118,7 @@ func extractAttestations(attestations []attestation) (*attestation, *attestation
 
 func (n *Npm) verifyProvenanceAttestationSignature() error {
 	// Re-use the standard bundle verification.
-	signedProvenance, err := VerifyProvenanceBundle(n.ctx, n.provenanceAttestation.BundleBytes, n.root)
+	signedProvenance, err := VerifyProvenanceBundle(n.ctx, n.provenanceAttestation.BundleBytes, n.root, n.verifierOpts)
 	if err != nil {
 		return err
 	}
@@ -142,52 +128,36 @@ func (n *Npm) verifyProvenanceAttestationSignature() error {
 
 func (n *Npm) verifyPublishAttesttationSignature() error {
 	// First verify the bundle and its rekor entry.
-	signedPublish, err := verifyBundleAndEntryFromBytes(n.ctx, n.publishAttestation.BundleBytes, n.root, false)
+	signedPublish, err := verifyBundleAndEntryFromBytes(n.ctx, n.publishAttestation.BundleBytes, n.root, false, n.verifierOpts)
 	if err != nil {
 		return err
 	}
 
-	// Second, we verify the signature, which uses a static key.
-	// Extract payload.
-	env := signedPublish.Envelope
-	payload, err := utils.PayloadFromEnvelope(env)
-	if err != nil {
-		return err
+	// Second, we verify the signature with the key of the npm registry
+	// selected by its keyid. The key must not have expired when the
+	// entry was added to the transparency log.
+	keysPath := ""
+	if n.verifierOpts != nil {
+		keysPath = n.verifierOpts.NpmRegistryKeysPath
 	}
-
-	// Extract the signature.
-	if len(env.Signatures) == 0 {
-		return fmt.Errorf("%w: no signatures found in envelope", serrors.ErrorNoValidSignature)
-	}
-
-	// The registry signs with a single, static, non-rotated key.
-	sig := env.Signatures[0].Sig
-	// TODO(#496): verify the keyid, both in DSSE and hint.
-
-	// Verify the signature.
-	payloadHash := sha256.Sum256(payload)
-	rawKey, err := base64.StdEncoding.DecodeString(npmRegistryPublicKey)
+	keys, err := npmRegistryKeysFromFile(keysPath)
 	if err != nil {
-		return fmt.Errorf("DecodeString: %w", err)
+		return err
 	}
 
-	key, err := x509.ParsePKIXPublicKey(rawKey)
+	bundle, err := unmarshalBundle(n.publishAttestation.BundleBytes)
 	if err != nil {
-		return fmt.Errorf("x509.ParsePKIXPublicKey: %w", err)
-	}
-
-	pubKey, ok := key.(*ecdsa.PublicKey)
-	if !ok {
-		return fmt.Errorf("%w: public key not of type ECDSA", err)
+		return err
 	}
+	hint := bundle.GetVerificationMaterial().GetPublicKey().GetHint()
 
-	rsig, err := utils.DecodeSignature(sig)
+	times, err := verifiedSignatureTimes(signedPublish)
 	if err != nil {
-		return fmt.Errorf("decodeSigature: %w: %s", serrors.ErrorInvalidEncoding, err)
+		return err
 	}
 
-	if ecdsa.VerifyASN1(pubKey, payloadHash[:], rsig) {
-		return fmt.Errorf("%w: %s", serrors.ErrorInvalidSignature, sig)
+	if err := keys.verifyEnvelope(signedPublish.Envelope, hint, times); err != nil {
+		return err
 	}
 
 	// Verification done.
@@ -243,11 +213,76 @@ func verifyIntotoTypes(att *SignedAttestation, predicateType, payloadType string
 	return nil
 }
 
+// prunedProvenance is the part of the SLSA v0.2 provenance of npm packages
+// that is verified against the certificate.
+type prunedProvenance struct {
+	intoto.StatementHeader
+	Predicate struct {
+		BuildType string `json:"buildType"`
+		Builder   struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Invocation struct {
+			ConfigSource struct {
+				URI        string `json:"uri,omitempty"`
+				EntryPoint string `json:"entryPoint,omitempty"`
+			} `json:"configSource"`
+			Environment any `json:"environment,omitempty"`
+		} `json:"invocation"`
+		Metadata *prunedProvenanceMetadata `json:"metadata,omitempty"`
+	} `json:"predicate"`
+}
+
+type prunedProvenanceMetadata struct {
+	BuildInvocationID string `json:"buildInvocationId,omitempty"`
+}
+
+// verifiedProvenanceBytes returns the verified provenance statement,
+// pruned to the fields verified against the certificate by
+// verifyProvenanceMatchesCertificate. The provenance of all builders is
+// pruned, so that fields that are not verified, such as the materials,
+// the parameters and the rest of the metadata, are not reported as verified.
 func (n *Npm) verifiedProvenanceBytes() ([]byte, error) {
-	// TODO(#493): prune the provenance and return only
-	// verified fields.
-	// NOTE: we currently don't verify the materials' commit sha.
-	return []byte{}, nil
+	payload, err := utils.PayloadFromEnvelope(n.verifiedProvenanceAtt.Envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	prov, err := slsaprovenance.ProvenanceFromStatement(payload)
+	if err != nil {
+		return nil, err
+	}
+	prov02, ok := prov.(slsav02.ProvenanceV02)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected v0.2 provenance", serrors.ErrorInvalidDssePayload)
+	}
+	subjects, err := prov.Subjects()
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: the materials and the config source digest are not verified
+	// against the certificate: the commit sha is in the GITHUB_SHA environment
+	// variable instead. The parameters and build config are verified to be empty.
+	predicate := prov02.Predicate()
+	statement := prunedProvenance{
+		StatementHeader: intoto.StatementHeader{
+			Type:          intoto.StatementInTotoV01,
+			PredicateType: common.ProvenanceV02Type,
+			Subject:       subjects,
+		},
+	}
+	statement.Predicate.BuildType = predicate.BuildType
+	statement.Predicate.Builder.ID = predicate.Builder.ID
+	statement.Predicate.Invocation.ConfigSource.URI = predicate.Invocation.ConfigSource.URI
+	statement.Predicate.Invocation.ConfigSource.EntryPoint = predicate.Invocation.ConfigSource.EntryPoint
+	statement.Predicate.Invocation.Environment = predicate.Invocation.Environment
+	if predicate.Metadata != nil {
+		statement.Predicate.Metadata = &prunedProvenanceMetadata{
+			BuildInvocationID: predicate.Metadata.BuildInvocationID,
+		}
+	}
+	return json.Marshal(statement)
 }
 
 func (n *Npm) verifyPackageName(name *string) error {
@@ -302,8 +337,13 @@ func verifyPublishPredicateVersion(att *SignedAttestation, expectedVersion strin
 		return err
 	}
 	if version != expectedVersion {
-		return fmt.Errorf("%w: got '%v', expected '%v'", serrors.ErrorMismatchPackageVersion,
-			version, expectedVersion)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchPackageVersion,
+			Check:    utils.CheckPackageVersion,
+			Field:    "publish attestation predicate.version",
+			Expected: expectedVersion,
+			Actual:   version,
+		}
 	}
 	return nil
 }
@@ -314,8 +354,13 @@ func verifyPublishPredicateName(att *SignedAttestation, expectedName string) err
 		return err
 	}
 	if name != expectedName {
-		return fmt.Errorf("%w: got '%v', expected '%v'", serrors.ErrorMismatchPackageName,
-			name, expectedName)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchPackageName,
+			Check:    utils.CheckPackageName,
+			Field:    "publish attestation predicate.name",
+			Expected: expectedName,
+			Actual:   name,
+		}
 	}
 	return nil
 }
@@ -353,8 +398,13 @@ func verifyProvenanceSubjectVersion(att *SignedAttestation, expectedVersion stri
 	}
 
 	if subVersion != expectedVersion {
-		return fmt.Errorf("%w: got '%v', expected '%v'", serrors.ErrorMismatchPackageVersion,
-			subVersion, expectedVersion)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchPackageVersion,
+			Check:    utils.CheckPackageVersion,
+			Field:    "provenance subject version",
+			Expected: expectedVersion,
+			Actual:   subVersion,
+		}
 	}
 
 	return nil
@@ -367,8 +417,13 @@ func verifyPublishSubjectVersion(att *SignedAttestation, expectedVersion string)
 	}
 
 	if version != expectedVersion {
-		return fmt.Errorf("%w: got '%v', expected '%v'", serrors.ErrorMismatchPackageVersion,
-			version, expectedVersion)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchPackageVersion,
+			Check:    utils.CheckPackageVersion,
+			Field:    "publish attestation predicate.version",
+			Expected: expectedVersion,
+			Actual:   version,
+		}
 	}
 
 	return nil
@@ -419,8 +474,13 @@ func verifyName(actual, expected string) error {
 	}
 
 	if subName != expected {
-		return fmt.Errorf("%w: got '%v', expected '%v'", serrors.ErrorMismatchPackageName,
-			subName, expected)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchPackageName,
+			Check:    utils.CheckPackageName,
+			Field:    "package name",
+			Expected: expected,
+			Actual:   subName,
+		}
 	}
 
 	return nil
@@ -0,0 +1,178 @@
+package gha
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+// defaultNpmRegistryKeys are the signing keys of the npm registry,
+// as published at https://registry.npmjs.org/-/npm/v1/keys.
+// See https://docs.npmjs.com/about-registry-signatures.
+//
+//go:embed materials/npm_registry_keys.json
+var defaultNpmRegistryKeys []byte
+
+// npmRegistryKeyScheme is the only supported signature scheme.
+const npmRegistryKeyScheme = "ecdsa-sha2-nistp256"
+
+// npmRegistryKeys is a keyring of the npm registry.
+type npmRegistryKeys struct {
+	Keys []npmRegistryKey `json:"keys"`
+}
+
+// npmRegistryKey is a signing key of the npm registry.
+type npmRegistryKey struct {
+	KeyID   string `json:"keyid"`
+	KeyType string `json:"keytype"`
+	Scheme  string `json:"scheme"`
+	// Key is the base64-encoded DER public key.
+	Key string `json:"key"`
+	// Expires is the time after which the key is no longer valid.
+	// It is nil if the key has not expired.
+	Expires *time.Time `json:"expires"`
+
+	// pubKey is nil for keys of unsupported schemes.
+	pubKey *ecdsa.PublicKey
+}
+
+// npmRegistryKeysFromFile reads a keyring in the format of
+// https://registry.npmjs.org/-/npm/v1/keys. If the path is empty,
+// the keys embedded in the verifier are used.
+func npmRegistryKeysFromFile(path string) (*npmRegistryKeys, error) {
+	if path == "" {
+		return npmRegistryKeysFromJSON(defaultNpmRegistryKeys)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: npm registry keys: %s", serrors.ErrorInvalidKey, err)
+	}
+	return npmRegistryKeysFromJSON(content)
+}
+
+// npmRegistryKeysFromJSON parses a keyring in the format of
+// https://registry.npmjs.org/-/npm/v1/keys.
+func npmRegistryKeysFromJSON(content []byte) (*npmRegistryKeys, error) {
+	var keys npmRegistryKeys
+	if err := json.Unmarshal(content, &keys); err != nil {
+		return nil, fmt.Errorf("%w: npm registry keys: %s", serrors.ErrorInvalidFormat, err)
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("%w: no npm registry keys", serrors.ErrorInvalidKey)
+	}
+
+	for i := range keys.Keys {
+		k := &keys.Keys[i]
+		if k.KeyID == "" {
+			return nil, fmt.Errorf("%w: npm registry key with empty keyid", serrors.ErrorInvalidKey)
+		}
+		// Keys of other schemes may be added by the registry. They are
+		// rejected when used instead.
+		if k.Scheme != npmRegistryKeyScheme {
+			continue
+		}
+		pubKey, err := parseNpmRegistryKey(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: npm registry key '%s': %v", serrors.ErrorInvalidKey, k.KeyID, err)
+		}
+		k.pubKey = pubKey
+	}
+	return &keys, nil
+}
+
+func parseNpmRegistryKey(key string) (*ecdsa.PublicKey, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeString: %w", err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("x509.ParsePKIXPublicKey: %w", err)
+	}
+	pubKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok || pubKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("public key not of type ECDSA P-256")
+	}
+	return pubKey, nil
+}
+
+// verifyEnvelope verifies the signatures of the envelope with the keys
+// selected by their keyid. If the bundle has a public key hint, the keyid
+// must match it. The key must not have expired at any of the verified
+// signature times. Verification succeeds with the first valid signature.
+func (r *npmRegistryKeys) verifyEnvelope(env *dsse.Envelope, hint string, times []time.Time) error {
+	payload, err := utils.PayloadFromEnvelope(env)
+	if err != nil {
+		return err
+	}
+	if len(env.Signatures) == 0 {
+		return fmt.Errorf("%w: no signatures found in envelope", serrors.ErrorNoValidSignature)
+	}
+
+	digest := sha256.Sum256(dsse.PAE(env.PayloadType, payload))
+	var firstErr error
+	for _, sig := range env.Signatures {
+		err := r.verifySignature(digest[:], sig, hint, times)
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (r *npmRegistryKeys) verifySignature(digest []byte, sig dsse.Signature, hint string, times []time.Time) error {
+	if hint != "" && sig.KeyID != hint {
+		return fmt.Errorf("%w: keyid '%s' does not match the public key hint '%s'",
+			serrors.ErrorNoValidSignature, sig.KeyID, hint)
+	}
+
+	key := r.key(sig.KeyID)
+	if key == nil {
+		return fmt.Errorf("%w: no npm registry key with keyid '%s'",
+			serrors.ErrorNoValidSignature, sig.KeyID)
+	}
+	if key.pubKey == nil {
+		return fmt.Errorf("%w: npm registry key '%s' with scheme '%s'",
+			serrors.ErrorNotSupported, key.KeyID, key.Scheme)
+	}
+	if key.Expires != nil {
+		for _, t := range times {
+			if t.After(*key.Expires) {
+				return fmt.Errorf("%w: npm registry key '%s' expired at %v, before the signature time %v",
+					serrors.ErrorInvalidKey, key.KeyID, key.Expires.UTC(), t.UTC())
+			}
+		}
+	}
+
+	rsig, err := utils.DecodeSignature(sig.Sig)
+	if err != nil {
+		return fmt.Errorf("decodeSigature: %w: %s", serrors.ErrorInvalidEncoding, err)
+	}
+	if !ecdsa.VerifyASN1(key.pubKey, digest, rsig) {
+		return fmt.Errorf("%w: npm registry key '%s'", serrors.ErrorInvalidSignature, key.KeyID)
+	}
+	return nil
+}
+
+func (r *npmRegistryKeys) key(keyID string) *npmRegistryKey {
+	for i := range r.Keys {
+		if r.Keys[i].KeyID == keyID {
+			return &r.Keys[i]
+		}
+	}
+	return nil
+}
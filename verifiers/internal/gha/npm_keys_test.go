@@ -0,0 +1,297 @@
+package gha
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+const npmRegistryKeyID = "SHA256:jl3bwswu80PjjokCgh0o2w5c2U4LhQAE57gj9cz1kzA"
+
+func Test_npmRegistryKeysFromJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		keys    int
+		err     error
+	}{
+		{
+			name:    "default keys",
+			content: string(defaultNpmRegistryKeys),
+			keys:    1,
+		},
+		{
+			name: "unsupported scheme",
+			content: `{"keys": [
+				{"keyid": "SHA256:other", "keytype": "ssh-ed25519", "scheme": "ssh-ed25519", "key": "not a key", "expires": null}
+			]}`,
+			keys: 1,
+		},
+		{
+			name:    "invalid json",
+			content: `{"keys": `,
+			err:     serrors.ErrorInvalidFormat,
+		},
+		{
+			name:    "no keys",
+			content: `{"keys": []}`,
+			err:     serrors.ErrorInvalidKey,
+		},
+		{
+			name: "empty keyid",
+			content: `{"keys": [
+				{"keyid": "", "keytype": "ecdsa-sha2-nistp256", "scheme": "ecdsa-sha2-nistp256", "key": "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE1Olb3zMAFFxXKHiIkQO5cJ3Yhl5i6UPp+IhuteBJbuHcA5UogKo0EWtlWwW6KSaKoTNEYL7JlCQiVnkhBktUgg==", "expires": null}
+			]}`,
+			err: serrors.ErrorInvalidKey,
+		},
+		{
+			name: "invalid key",
+			content: `{"keys": [
+				{"keyid": "SHA256:invalid", "keytype": "ecdsa-sha2-nistp256", "scheme": "ecdsa-sha2-nistp256", "key": "bm90IGEga2V5", "expires": null}
+			]}`,
+			err: serrors.ErrorInvalidKey,
+		},
+		{
+			name: "invalid expiry",
+			content: `{"keys": [
+				{"keyid": "SHA256:invalid", "keytype": "ecdsa-sha2-nistp256", "scheme": "ecdsa-sha2-nistp256", "key": "", "expires": "tomorrow"}
+			]}`,
+			err: serrors.ErrorInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, err := npmRegistryKeysFromJSON([]byte(tt.content))
+			if !errCmp(err, tt.err) {
+				t.Fatalf(cmp.Diff(err, tt.err))
+			}
+			if err != nil {
+				return
+			}
+			if len(keys.Keys) != tt.keys {
+				t.Errorf("unexpected number of keys: %d", len(keys.Keys))
+			}
+		})
+	}
+}
+
+func Test_npmRegistryKeys_verifyEnvelope(t *testing.T) {
+	t.Parallel()
+
+	signedAt := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	before := signedAt.Add(-time.Hour)
+	after := signedAt.Add(time.Hour)
+
+	newKey := func(keyID string, expires *time.Time) (*ecdsa.PrivateKey, npmRegistryKey) {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return priv, npmRegistryKey{
+			KeyID:   keyID,
+			KeyType: npmRegistryKeyScheme,
+			Scheme:  npmRegistryKeyScheme,
+			Key:     base64.StdEncoding.EncodeToString(der),
+			Expires: expires,
+			pubKey:  &priv.PublicKey,
+		}
+	}
+	oldPriv, oldKey := newKey("SHA256:old", &after)
+	newPriv, newKeyEntry := newKey("SHA256:new", nil)
+	_, expiredKey := newKey("SHA256:expired", &before)
+	unsupportedKey := npmRegistryKey{KeyID: "SHA256:unsupported", Scheme: "ssh-ed25519"}
+	keys := &npmRegistryKeys{
+		Keys: []npmRegistryKey{oldKey, newKeyEntry, expiredKey, unsupportedKey},
+	}
+
+	payload := []byte(`{"_type": "https://in-toto.io/Statement/v0.1"}`)
+	sign := func(priv *ecdsa.PrivateKey, keyID string) dsse.Signature {
+		digest := sha256.Sum256(dsse.PAE("application/vnd.in-toto+json", payload))
+		sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dsse.Signature{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}
+	}
+	envelope := func(sigs ...dsse.Signature) *dsse.Envelope {
+		return &dsse.Envelope{
+			PayloadType: "application/vnd.in-toto+json",
+			Payload:     base64.StdEncoding.EncodeToString(payload),
+			Signatures:  sigs,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		env   *dsse.Envelope
+		hint  string
+		times []time.Time
+		err   error
+	}{
+		{
+			name:  "key without expiry",
+			env:   envelope(sign(newPriv, "SHA256:new")),
+			times: []time.Time{signedAt},
+		},
+		{
+			name:  "key not expired",
+			env:   envelope(sign(oldPriv, "SHA256:old")),
+			hint:  "SHA256:old",
+			times: []time.Time{signedAt},
+		},
+		{
+			name:  "key expired before signature time",
+			env:   envelope(sign(oldPriv, "SHA256:old")),
+			times: []time.Time{signedAt, after.Add(time.Second)},
+			err:   serrors.ErrorInvalidKey,
+		},
+		{
+			name:  "expired key",
+			env:   envelope(sign(oldPriv, "SHA256:expired")),
+			times: []time.Time{signedAt},
+			err:   serrors.ErrorInvalidKey,
+		},
+		{
+			name:  "signature of another key",
+			env:   envelope(sign(oldPriv, "SHA256:new")),
+			times: []time.Time{signedAt},
+			err:   serrors.ErrorInvalidSignature,
+		},
+		{
+			name:  "unknown keyid",
+			env:   envelope(sign(newPriv, "SHA256:unknown")),
+			times: []time.Time{signedAt},
+			err:   serrors.ErrorNoValidSignature,
+		},
+		{
+			name:  "empty keyid",
+			env:   envelope(sign(newPriv, "")),
+			times: []time.Time{signedAt},
+			err:   serrors.ErrorNoValidSignature,
+		},
+		{
+			name:  "mismatch hint",
+			env:   envelope(sign(newPriv, "SHA256:new")),
+			hint:  "SHA256:old",
+			times: []time.Time{signedAt},
+			err:   serrors.ErrorNoValidSignature,
+		},
+		{
+			name:  "unsupported scheme",
+			env:   envelope(sign(newPriv, "SHA256:unsupported")),
+			times: []time.Time{signedAt},
+			err:   serrors.ErrorNotSupported,
+		},
+		{
+			name:  "one valid signature",
+			env:   envelope(sign(newPriv, "SHA256:unknown"), sign(newPriv, "SHA256:new")),
+			times: []time.Time{signedAt},
+		},
+		{
+			name:  "no signatures",
+			env:   envelope(),
+			times: []time.Time{signedAt},
+			err:   serrors.ErrorNoValidSignature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := keys.verifyEnvelope(tt.env, tt.hint, tt.times)
+			if !errCmp(err, tt.err) {
+				t.Errorf(cmp.Diff(err, tt.err))
+			}
+		})
+	}
+}
+
+func Test_verifyPublishAttesttationSignature(t *testing.T) {
+	t.Parallel()
+
+	trustedRoot, err := TrustedRootFromFile("./testdata/trusted_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join("testdata", "npm-attestations.intoto.sigstore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The publish attestation was added to the transparency log on 2023-02-15.
+	key := `{"keyid": "%s", "keytype": "ecdsa-sha2-nistp256", "scheme": "ecdsa-sha2-nistp256", "key": "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE1Olb3zMAFFxXKHiIkQO5cJ3Yhl5i6UPp+IhuteBJbuHcA5UogKo0EWtlWwW6KSaKoTNEYL7JlCQiVnkhBktUgg==", "expires": %s}`
+	tests := []struct {
+		name string
+		// keys is the content of the keys file. The default keys are used if empty.
+		keys string
+		err  error
+	}{
+		{
+			name: "default keys",
+		},
+		{
+			name: "rotated keys",
+			keys: `{"keys": [` +
+				fmt.Sprintf(key, npmRegistryKeyID, `"2025-01-29T00:00:00.000Z"`) + `,` +
+				fmt.Sprintf(key, "SHA256:rotated", "null") + `]}`,
+		},
+		{
+			name: "key expired",
+			keys: `{"keys": [` + fmt.Sprintf(key, npmRegistryKeyID, `"2023-01-01T00:00:00.000Z"`) + `]}`,
+			err:  serrors.ErrorInvalidKey,
+		},
+		{
+			name: "no key for keyid",
+			keys: `{"keys": [` + fmt.Sprintf(key, "SHA256:rotated", "null") + `]}`,
+			err:  serrors.ErrorNoValidSignature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifierOpts := &options.VerifierOpts{}
+			if tt.keys != "" {
+				verifierOpts.NpmRegistryKeysPath = filepath.Join(t.TempDir(), "keys.json")
+				if err := os.WriteFile(verifierOpts.NpmRegistryKeysPath, []byte(tt.keys), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			n, err := NpmNew(context.Background(), trustedRoot, content, verifierOpts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = n.verifyPublishAttesttationSignature()
+			if !errCmp(err, tt.err) {
+				t.Errorf(cmp.Diff(err, tt.err))
+			}
+		})
+	}
+}
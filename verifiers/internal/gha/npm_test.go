@@ -2,9 +2,12 @@ package gha
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -551,7 +554,7 @@ func Test_verifyPackageName(t *testing.T) {
 				panic(fmt.Errorf("os.ReadFile: %w", err))
 			}
 
-			npm, err := NpmNew(ctx, trustedRoot, content)
+			npm, err := NpmNew(ctx, trustedRoot, content, nil)
 			if err != nil {
 				panic(fmt.Errorf("NpmNew: %w", err))
 			}
@@ -629,7 +632,7 @@ func Test_verifyPackageVersion(t *testing.T) {
 				panic(fmt.Errorf("os.ReadFile: %w", err))
 			}
 
-			npm, err := NpmNew(ctx, trustedRoot, content)
+			npm, err := NpmNew(ctx, trustedRoot, content, nil)
 			if err != nil {
 				panic(fmt.Errorf("NpmNew: %w", err))
 			}
@@ -816,7 +819,7 @@ func Test_verifyIntotoHeaders(t *testing.T) {
 				panic(fmt.Errorf("os.ReadFile: %w", err))
 			}
 
-			npm, err := NpmNew(ctx, trustedRoot, content)
+			npm, err := NpmNew(ctx, trustedRoot, content, nil)
 			if err != nil {
 				panic(fmt.Errorf("NpmNew: %w", err))
 			}
@@ -885,10 +888,194 @@ func Test_NpmNew(t *testing.T) {
 				panic(fmt.Errorf("os.ReadFile: %w", err))
 			}
 
-			_, err = NpmNew(ctx, trustedRoot, content)
+			_, err = NpmNew(ctx, trustedRoot, content, nil)
 			if !errCmp(err, tt.err) {
 				t.Errorf(cmp.Diff(err, tt.err))
 			}
 		})
 	}
 }
+
+func Test_verifiedProvenanceBytes(t *testing.T) {
+	t.Parallel()
+
+	provenance := `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "subject": [
+    {
+      "name": "pkg:npm/%40laurentsimon/provenance-npm-test@1.0.0",
+      "digest": {"sha512": "29d19f26233f4441328412b34fd73ed104ecfef62f14097890cccf7455b521b65c5acff851849faa85c85395aa22d401436f01f3afb61b19c780e906c88c7f20"}
+    }
+  ],
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "predicate": {
+    "buildType": "https://github.com/npm/cli/gha@v1",
+    "builder": {"id": "https://github.com/npm/cli@9.5.0"},
+    "invocation": {
+      "configSource": {
+        "uri": "git+https://github.com/laurentsimon/provenance-npm-test@refs/heads/main",
+        "digest": {"sha1": "b38894f2dda4355ea5606fccb166e61565e12a14"},
+        "entryPoint": ".github/workflows/release.yml"
+      },
+      "parameters": {},
+      "environment": {
+        "GITHUB_REF": "refs/heads/main",
+        "GITHUB_SHA": "b38894f2dda4355ea5606fccb166e61565e12a14"
+      }
+    },
+    "metadata": {
+      "buildInvocationId": "4757060009-1",
+      "completeness": {"parameters": false, "environment": false, "materials": false},
+      "reproducible": false
+    },
+    "materials": [
+      {
+        "uri": "git+https://github.com/laurentsimon/provenance-npm-test",
+        "digest": {"sha1": "b38894f2dda4355ea5606fccb166e61565e12a14"}
+      }
+    ]
+  }
+}`
+	pruned := `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "subject": [
+    {
+      "name": "pkg:npm/%40laurentsimon/provenance-npm-test@1.0.0",
+      "digest": {"sha512": "29d19f26233f4441328412b34fd73ed104ecfef62f14097890cccf7455b521b65c5acff851849faa85c85395aa22d401436f01f3afb61b19c780e906c88c7f20"}
+    }
+  ],
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "predicate": {
+    "buildType": "https://github.com/npm/cli/gha@v1",
+    "builder": {"id": "https://github.com/npm/cli@9.5.0"},
+    "invocation": {
+      "configSource": {
+        "uri": "git+https://github.com/laurentsimon/provenance-npm-test@refs/heads/main",
+        "entryPoint": ".github/workflows/release.yml"
+      },
+      "environment": {
+        "GITHUB_REF": "refs/heads/main",
+        "GITHUB_SHA": "b38894f2dda4355ea5606fccb166e61565e12a14"
+      }
+    },
+    "metadata": {
+      "buildInvocationId": "4757060009-1"
+    }
+  }
+}`
+
+	// The provenance of the Node.js builder has parameters and full metadata.
+	builderProvenance := `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "subject": [
+    {
+      "name": "pkg:npm/%40ianlewis/actions-test@0.1.127",
+      "digest": {"sha512": "b9e3a5a2d3ac5b1a7d8e3b5b4b7cd3f3ca0db4b2b2e7d95c5b3e4d7d1a7c6f2f8d8e0b8c9e0e1b5c6e4a3d2f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0"}
+    }
+  ],
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "predicate": {
+    "buildType": "https://github.com/slsa-framework/slsa-github-generator/delegator-generic@v0",
+    "builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_nodejs_slsa3.yml@refs/tags/v1.6.0"},
+    "invocation": {
+      "configSource": {
+        "uri": "git+https://github.com/ianlewis/actions-test@refs/tags/v0.1.127",
+        "digest": {"sha1": "d9e3bd0ff52f8d1ba5bd5e2c0dbf1b2f2b1ef4e2"},
+        "entryPoint": ".github/workflows/nodejs.yml"
+      },
+      "parameters": {"inputs": {"run-scripts": "ci, build"}},
+      "environment": {"github_event_name": "push", "github_sha1": "d9e3bd0ff52f8d1ba5bd5e2c0dbf1b2f2b1ef4e2"}
+    },
+    "buildConfig": {"version": 1},
+    "metadata": {
+      "buildInvocationId": "4757060010-1",
+      "completeness": {"parameters": true, "environment": false, "materials": false},
+      "reproducible": false
+    },
+    "materials": [
+      {
+        "uri": "git+https://github.com/ianlewis/actions-test@refs/tags/v0.1.127",
+        "digest": {"sha1": "d9e3bd0ff52f8d1ba5bd5e2c0dbf1b2f2b1ef4e2"}
+      }
+    ]
+  }
+}`
+	builderPruned := `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "subject": [
+    {
+      "name": "pkg:npm/%40ianlewis/actions-test@0.1.127",
+      "digest": {"sha512": "b9e3a5a2d3ac5b1a7d8e3b5b4b7cd3f3ca0db4b2b2e7d95c5b3e4d7d1a7c6f2f8d8e0b8c9e0e1b5c6e4a3d2f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0"}
+    }
+  ],
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "predicate": {
+    "buildType": "https://github.com/slsa-framework/slsa-github-generator/delegator-generic@v0",
+    "builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_nodejs_slsa3.yml@refs/tags/v1.6.0"},
+    "invocation": {
+      "configSource": {
+        "uri": "git+https://github.com/ianlewis/actions-test@refs/tags/v0.1.127",
+        "entryPoint": ".github/workflows/nodejs.yml"
+      },
+      "environment": {"github_event_name": "push", "github_sha1": "d9e3bd0ff52f8d1ba5bd5e2c0dbf1b2f2b1ef4e2"}
+    },
+    "metadata": {
+      "buildInvocationId": "4757060010-1"
+    }
+  }
+}`
+
+	tests := []struct {
+		name       string
+		provenance string
+		expected   string
+	}{
+		{
+			name:       "npm CLI",
+			provenance: provenance,
+			expected:   pruned,
+		},
+		{
+			name:       "trusted builder",
+			provenance: builderProvenance,
+			expected:   builderPruned,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := &Npm{
+				verifiedProvenanceAtt: &SignedAttestation{
+					Envelope: &dsselib.Envelope{
+						PayloadType: "application/vnd.in-toto+json",
+						Payload:     base64.StdEncoding.EncodeToString([]byte(tt.provenance)),
+					},
+				},
+			}
+
+			b, err := n.verifiedProvenanceBytes()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// The fields that are not verified are dropped.
+			for _, field := range []string{"materials", "parameters", "buildConfig", "completeness", "reproducible"} {
+				if strings.Contains(string(b), `"`+field+`"`) {
+					t.Errorf("unverified field %q in provenance: %s", field, b)
+				}
+			}
+
+			var got, expected any
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("json.Unmarshal: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+				t.Fatalf("json.Unmarshal: %v", err)
+			}
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("unexpected provenance (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -1,12 +1,13 @@
 package gha
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/sigstore/rekor/pkg/generated/client"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/slsa-framework/slsa-github-generator/signing/envelope"
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance/common"
@@ -30,6 +32,8 @@ type SignedAttestation struct {
 	SigningCert *x509.Certificate
 	// The associated verified Rekor entry
 	RekorEntry *models.LogEntryAnon
+	// The times of the verified RFC 3161 timestamps of the signature
+	Timestamps []time.Time
 }
 
 // EnvelopeFromBytes reads a DSSE envelope from the given payload.
@@ -39,6 +43,42 @@ func EnvelopeFromBytes(payload []byte) (env *dsselib.Envelope, err error) {
 	return
 }
 
+// provenanceLine is an attestation in a JSON Lines provenance file.
+type provenanceLine struct {
+	// The 1-based line number.
+	number  int
+	content []byte
+	// The error for a line that is not valid JSON.
+	err error
+}
+
+// provenanceLines splits a provenance file in the JSON Lines format
+// into its attestations. It returns nil if the provenance is a single JSON
+// document, which may span several lines. A line that is not valid JSON
+// is returned with its error so that the other lines can still be verified.
+func provenanceLines(provenance []byte) ([]provenanceLine, error) {
+	if json.Valid(provenance) {
+		return nil, nil
+	}
+
+	var lines []provenanceLine
+	for i, l := range bytes.Split(provenance, []byte("\n")) {
+		l = bytes.TrimSpace(l)
+		if len(l) == 0 {
+			continue
+		}
+		line := provenanceLine{number: i + 1, content: l}
+		if !json.Valid(l) {
+			line.err = fmt.Errorf("%w: line %d is not valid JSON", serrors.ErrorInvalidFormat, i+1)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: empty provenance", serrors.ErrorInvalidFormat)
+	}
+	return lines, nil
+}
+
 // Verify Builder ID in provenance statement.
 // This function does an exact comparison, and expects expectedBuilderID to be the full
 // `name@refs/tags/<name>`.
@@ -110,8 +150,13 @@ func verifySourceURI(prov iface.Provenance, expectedSourceURI string, allowNoMat
 		return err
 	}
 	if configURI != source {
-		return fmt.Errorf("%w: expected source '%s' in configSource.uri, got '%s'", serrors.ErrorMismatchSource,
-			source, fullConfigURI)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchSource,
+			Check:    utils.CheckSourceURI,
+			Field:    "configSource.uri",
+			Expected: source,
+			Actual:   fullConfigURI,
+		}
 	}
 
 	// Verify source from material section.
@@ -125,8 +170,13 @@ func verifySourceURI(prov iface.Provenance, expectedSourceURI string, allowNoMat
 		return err
 	}
 	if materialURI != source {
-		return fmt.Errorf("%w: expected source '%s' in material section, got '%s'", serrors.ErrorMismatchSource,
-			source, materialSourceURI)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchSource,
+			Check:    utils.CheckSourceURI,
+			Field:    "materials.uri",
+			Expected: source,
+			Actual:   materialSourceURI,
+		}
 	}
 
 	// Last, verify that both fields match.
@@ -184,6 +234,7 @@ func verifyDigest(prov iface.Provenance, expectedHash string) error {
 		return fmt.Errorf("%w: expected minimum 256-bit. Got %d", serrors.ErrorInvalidHash, bitLength)
 	}
 
+	var hashes []string
 	for _, subject := range subjects {
 		digestSet := subject.Digest
 		hash, exists := digestSet[expectedAlgo]
@@ -193,31 +244,39 @@ func verifyDigest(prov iface.Provenance, expectedHash string) error {
 		if hash == expectedHash {
 			return nil
 		}
+		hashes = append(hashes, hash)
 	}
 
-	return fmt.Errorf("expected hash '%s' not found: %w", expectedHash, serrors.ErrorMismatchHash)
+	return &serrors.VerificationError{
+		Err:      serrors.ErrorMismatchHash,
+		Check:    utils.CheckSubjectDigest,
+		Field:    "subject.digest." + expectedAlgo,
+		Expected: expectedHash,
+		Actual:   strings.Join(hashes, ","),
+	}
 }
 
 // VerifyProvenanceSignature returns the verified DSSE envelope containing the provenance
 // and the signing certificate given the provenance and artifact hash.
 func VerifyProvenanceSignature(ctx context.Context, trustedRoot *TrustedRoot,
 	rClient *client.Rekor,
-	provenance []byte, artifactHash string) (
+	provenance []byte, artifactHash string,
+	verifierOpts *options.VerifierOpts) (
 	*SignedAttestation, error,
 ) {
 	// There are two cases, either we have an embedded certificate, or we need
 	// to use the Redis index for searching by artifact SHA.
 	if hasCertInEnvelope(provenance) {
 		// Get Rekor entries corresponding to provenance
-		return GetValidSignedAttestationWithCert(rClient, provenance, trustedRoot)
+		return GetValidSignedAttestationWithCert(ctx, rClient, provenance, trustedRoot, verifierOpts)
 	}
 
 	// Fallback on using the redis search index to get matching UUIDs.
-	fmt.Fprintf(os.Stderr, "No certificate provided, trying Redis search index to find entries by subject digest\n")
+	events.Emit(ctx, events.Info{Message: "No certificate provided, trying Redis search index to find entries by subject digest"})
 
 	// Verify the provenance and return the signing certificate.
 	return SearchValidSignedAttestation(ctx, artifactHash,
-		provenance, rClient, trustedRoot)
+		provenance, rClient, trustedRoot, verifierOpts)
 }
 
 // VerifyNpmPackageProvenance verifies provenance for an npm package.
@@ -368,6 +427,25 @@ func VerifyProvenanceCommonOptions(prov iface.Provenance, provenanceOpts *option
 	return nil
 }
 
+// provenanceChecks returns the names of the checks performed by
+// VerifyProvenanceCommonOptions for the given options.
+func provenanceChecks(provenanceOpts *options.ProvenanceOpts) []string {
+	checks := []string{utils.CheckSourceURI, utils.CheckSubjectDigest}
+	if provenanceOpts.ExpectedBranch != nil {
+		checks = append(checks, utils.CheckBranch)
+	}
+	if provenanceOpts.ExpectedTag != nil {
+		checks = append(checks, utils.CheckTag)
+	}
+	if provenanceOpts.ExpectedVersionedTag != nil {
+		checks = append(checks, utils.CheckVersionedTag)
+	}
+	if len(provenanceOpts.ExpectedWorkflowInputs) > 0 {
+		checks = append(checks, utils.CheckWorkflowInputs)
+	}
+	return checks
+}
+
 // VerifyWorkflowInputs verifies that the workflow inputs in the provenance
 // match the expected values.
 func VerifyWorkflowInputs(prov iface.Provenance, inputs map[string]string) error {
@@ -384,8 +462,13 @@ func VerifyWorkflowInputs(prov iface.Provenance, inputs map[string]string) error
 		}
 
 		if v != value {
-			return fmt.Errorf("%w: expected '%s=%s', got '%s=%s'",
-				serrors.ErrorMismatchWorkflowInputs, k, v, k, value)
+			return &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchWorkflowInputs,
+				Check:    utils.CheckWorkflowInputs,
+				Field:    "inputs." + k,
+				Expected: v,
+				Actual:   value,
+			}
 		}
 	}
 
@@ -406,7 +489,13 @@ func VerifyBranch(prov iface.Provenance, expectedBranch string) error {
 	}
 
 	if branch != expectedBranch {
-		return fmt.Errorf("expected branch '%s', got '%s': %w", expectedBranch, branch, serrors.ErrorMismatchBranch)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchBranch,
+			Check:    utils.CheckBranch,
+			Field:    "branch",
+			Expected: expectedBranch,
+			Actual:   branch,
+		}
 	}
 
 	return nil
@@ -426,7 +515,13 @@ func VerifyTag(prov iface.Provenance, expectedTag string) error {
 	}
 
 	if tag != expectedTag {
-		return fmt.Errorf("expected tag '%s', got '%s': %w", expectedTag, tag, serrors.ErrorMismatchTag)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchTag,
+			Check:    utils.CheckTag,
+			Field:    "tag",
+			Expected: expectedTag,
+			Actual:   tag,
+		}
 	}
 
 	return nil
@@ -1,11 +1,13 @@
 package gha
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	intoto "github.com/in-toto/in-toto-golang/in_toto"
 	"github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
 	slsa02 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
@@ -14,6 +16,7 @@ import (
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance/iface"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
 type testProvenance struct {
@@ -108,6 +111,74 @@ func Test_ProvenanceFromEnvelope(t *testing.T) {
 	}
 }
 
+func Test_provenanceLines(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		provenance string
+		lines      []int
+		invalid    []int
+		expected   error
+	}{
+		{
+			name:       "single document",
+			provenance: "{\"payloadType\": \"application/vnd.in-toto+json\"}\n",
+		},
+		{
+			name:       "single multi-line document",
+			provenance: "{\n  \"payloadType\": \"application/vnd.in-toto+json\"\n}\n",
+		},
+		{
+			name:       "multiple lines",
+			provenance: "{\"a\": 1}\n{\"b\": 2}\n",
+			lines:      []int{1, 2},
+		},
+		{
+			name:       "empty lines",
+			provenance: "{\"a\": 1}\n\n  \n{\"b\": 2}",
+			lines:      []int{1, 4},
+		},
+		{
+			name:       "invalid line",
+			provenance: "{\"a\": 1}\n{\"b\": \n",
+			lines:      []int{1, 2},
+			invalid:    []int{2},
+		},
+		{
+			name:       "empty",
+			provenance: " \n",
+			expected:   serrors.ErrorInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lines, err := provenanceLines([]byte(tt.provenance))
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+			var numbers, invalid []int
+			for _, l := range lines {
+				numbers = append(numbers, l.number)
+				if l.err != nil {
+					if !errCmp(l.err, serrors.ErrorInvalidFormat) {
+						t.Errorf(cmp.Diff(l.err, serrors.ErrorInvalidFormat))
+					}
+					invalid = append(invalid, l.number)
+				}
+			}
+			if diff := cmp.Diff(tt.lines, numbers); diff != "" {
+				t.Errorf("unexpected lines (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.invalid, invalid); diff != "" {
+				t.Errorf("unexpected invalid lines (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_VerifyDigest(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -527,6 +598,7 @@ func Test_VerifyBranch(t *testing.T) {
 		prov     iface.Provenance
 		branch   string
 		expected error
+		details  *serrors.VerificationError
 	}{
 		{
 			name: "branch slsa1",
@@ -542,6 +614,13 @@ func Test_VerifyBranch(t *testing.T) {
 			},
 			branch:   "slsa2",
 			expected: serrors.ErrorMismatchBranch,
+			details: &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchBranch,
+				Check:    utils.CheckBranch,
+				Field:    "branch",
+				Expected: "slsa2",
+				Actual:   "slsa1",
+			},
 		},
 		{
 			name: "case sensitive branch mismatch",
@@ -580,9 +659,19 @@ func Test_VerifyBranch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			if err := VerifyBranch(tt.prov, tt.branch); !errCmp(err, tt.expected) {
+			err := VerifyBranch(tt.prov, tt.branch)
+			if !errCmp(err, tt.expected) {
 				t.Errorf(cmp.Diff(err, tt.expected))
 			}
+			if tt.details != nil {
+				var verr *serrors.VerificationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("expected a VerificationError, got %v", err)
+				}
+				if diff := cmp.Diff(*tt.details, *verr, cmpopts.EquateErrors()); diff != "" {
+					t.Errorf("unexpected details (-want +got): \n%s", diff)
+				}
+			}
 		})
 	}
 }
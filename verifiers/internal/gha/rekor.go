@@ -5,11 +5,12 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
 	"github.com/sigstore/rekor/pkg/generated/client"
 	"github.com/sigstore/rekor/pkg/generated/client/entries"
@@ -32,20 +34,75 @@ import (
 	"github.com/slsa-framework/slsa-github-generator/signing/envelope"
 
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
 const (
 	defaultRekorAddr = "https://rekor.sigstore.dev"
 )
 
+// rekorAddr returns the address of the Rekor instance to use.
+func rekorAddr(verifierOpts *options.VerifierOpts) string {
+	if verifierOpts == nil || verifierOpts.RekorURL == "" {
+		return defaultRekorAddr
+	}
+	return strings.TrimSuffix(verifierOpts.RekorURL, "/")
+}
+
+// TlogEntryResult returns a verified Rekor entry in the form exposed by
+// utils.VerificationResult.
+func TlogEntryResult(e *models.LogEntryAnon, verifierOpts *options.VerifierOpts) *utils.TransparencyLogEntry {
+	if e == nil || e.LogIndex == nil {
+		return nil
+	}
+	entry := &utils.TransparencyLogEntry{
+		URL:      fmt.Sprintf("%v/%v?logIndex=%d", rekorAddr(verifierOpts), "api/v1/log/entries", *e.LogIndex),
+		LogIndex: *e.LogIndex,
+	}
+	if e.LogID != nil {
+		entry.LogID = *e.LogID
+	}
+	if e.IntegratedTime != nil {
+		entry.IntegratedTime = time.Unix(*e.IntegratedTime, 0)
+	}
+	return entry
+}
+
+// emitRekorRequest emits the outcome of a request to the Rekor API
+// sent at start.
+func emitRekorRequest(ctx context.Context, operation string, start time.Time, err error) {
+	events.Emit(ctx, events.RekorRequest{
+		Operation: operation,
+		Duration:  time.Since(start),
+		Err:       err,
+	})
+}
+
+// verifyTlogEntryByUUID fetches and verifies the Rekor entry with the UUID.
+// A valid entry in the cache is used instead of fetching it.
 func verifyTlogEntryByUUID(ctx context.Context, rekorClient *client.Rekor,
-	entryUUID string, trustedRoot *TrustedRoot) (
+	entryUUID string, trustedRoot *TrustedRoot, cache *tlogCache) (
 	*models.LogEntryAnon, error,
 ) {
+	uuid, err := sharding.GetUUIDFromIDString(entryUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if e := cache.entry(uuid); e != nil {
+		if entry, err := verifyTlogEntry(ctx, *e, true, trustedRoot.RekorPubKeys); err == nil {
+			return entry, nil
+		}
+	}
+
 	params := entries.NewGetLogEntryByUUIDParamsWithContext(ctx)
 	params.EntryUUID = entryUUID
 
+	start := time.Now()
 	lep, err := rekorClient.Entries.GetLogEntryByUUID(params)
+	emitRekorRequest(ctx, "GetLogEntryByUUID", start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -54,11 +111,6 @@ func verifyTlogEntryByUUID(ctx context.Context, rekorClient *client.Rekor,
 		return nil, errors.New("UUID value can not be extracted")
 	}
 
-	uuid, err := sharding.GetUUIDFromIDString(params.EntryUUID)
-	if err != nil {
-		return nil, err
-	}
-
 	for k, entry := range lep.Payload {
 		returnUUID, err := sharding.GetUUIDFromIDString(k)
 		if err != nil {
@@ -69,7 +121,12 @@ func verifyTlogEntryByUUID(ctx context.Context, rekorClient *client.Rekor,
 			return nil, errors.New("expected matching UUID")
 		}
 		// Validate the entry response.
-		return verifyTlogEntry(ctx, entry, true, trustedRoot.RekorPubKeys)
+		verified, err := verifyTlogEntry(ctx, entry, true, trustedRoot.RekorPubKeys)
+		if err != nil {
+			return nil, err
+		}
+		cache.putEntry(ctx, uuid, verified)
+		return verified, nil
 	}
 
 	return nil, serrors.ErrorRekorSearch
@@ -82,11 +139,9 @@ func verifyTlogEntry(ctx context.Context, e models.LogEntryAnon,
 	verifyInclusion bool, rekorKeys *cosign.TrustedTransparencyLogPubKeys) (
 	*models.LogEntryAnon, error,
 ) {
-	// Verify the root hash against the current Signed Entry Tree Head
-	verifier, err := signature.LoadECDSAVerifier(rekorKeys.Keys[*e.LogID].PubKey.(*ecdsa.PublicKey),
-		crypto.SHA256)
+	verifier, err := rekorVerifier(e.LogID, rekorKeys)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", serrors.ErrorRekorPubKey, err)
+		return nil, err
 	}
 
 	if verifyInclusion {
@@ -105,8 +160,59 @@ func verifyTlogEntry(ctx context.Context, e models.LogEntryAnon,
 	return &e, nil
 }
 
-func extractCert(e *models.LogEntryAnon) (*x509.Certificate, error) {
-	b, err := base64.StdEncoding.DecodeString(e.Body.(string))
+// verifyTlogInclusion verifies the inclusion proof of a Rekor entry
+// against its signed checkpoint, for entries without a SignedEntryTimestamp.
+// The integrated time of the entry is not verified.
+func verifyTlogInclusion(ctx context.Context, e models.LogEntryAnon,
+	rekorKeys *cosign.TrustedTransparencyLogPubKeys,
+) error {
+	verifier, err := rekorVerifier(e.LogID, rekorKeys)
+	if err != nil {
+		return err
+	}
+	if e.Verification == nil || e.Verification.InclusionProof == nil ||
+		e.Verification.InclusionProof.Checkpoint == nil {
+		return fmt.Errorf("%w: missing inclusion proof or checkpoint", serrors.ErrorInvalidRekorEntry)
+	}
+
+	if err := rverify.VerifyInclusion(ctx, &e); err != nil {
+		return fmt.Errorf("%w: %s", serrors.ErrorInvalidRekorEntry, err)
+	}
+	if err := rverify.VerifyCheckpointSignature(&e, verifier); err != nil {
+		return fmt.Errorf("%w: %s", serrors.ErrorInvalidRekorEntry, err)
+	}
+	return nil
+}
+
+// rekorVerifier returns the verifier of the trusted Rekor key with the log ID.
+func rekorVerifier(logID *string, rekorKeys *cosign.TrustedTransparencyLogPubKeys) (
+	signature.Verifier, error,
+) {
+	if logID == nil {
+		return nil, fmt.Errorf("%w: missing log ID", serrors.ErrorInvalidRekorEntry)
+	}
+	rekorKey, ok := rekorKeys.Keys[*logID]
+	if !ok {
+		return nil, fmt.Errorf("%w: no trusted key for log ID %s", serrors.ErrorRekorPubKey, *logID)
+	}
+	pubKey, ok := rekorKey.PubKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: public key not of type ECDSA", serrors.ErrorRekorPubKey)
+	}
+	verifier, err := signature.LoadECDSAVerifier(pubKey, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorRekorPubKey, err)
+	}
+	return verifier, nil
+}
+
+// intotoLogEntry returns the intoto entry in the body of a Rekor entry.
+func intotoLogEntry(e *models.LogEntryAnon) (*intotod.V001Entry, error) {
+	body, ok := e.Body.(string)
+	if !ok {
+		return nil, errors.New("unexpected tlog entry body")
+	}
+	b, err := base64.StdEncoding.DecodeString(body)
 	if err != nil {
 		return nil, err
 	}
@@ -121,16 +227,46 @@ func extractCert(e *models.LogEntryAnon) (*x509.Certificate, error) {
 		return nil, err
 	}
 
-	var publicKeyB64 []byte
-	switch e := eimpl.(type) {
-	case *intotod.V001Entry:
-		publicKeyB64, err = e.IntotoObj.PublicKey.MarshalText()
-		if err != nil {
-			return nil, err
-		}
-	default:
+	intotoEntry, ok := eimpl.(*intotod.V001Entry)
+	if !ok {
 		return nil, errors.New("unexpected tlog entry type")
 	}
+	return intotoEntry, nil
+}
+
+// entryMatchesEnvelope checks that the Rekor entry was created for the
+// envelope and the certificate, as when Rekor is searched for the entry.
+func entryMatchesEnvelope(e *models.LogEntryAnon, provenance []byte, cert *x509.Certificate) error {
+	intotoEntry, err := intotoLogEntry(e)
+	if err != nil {
+		return err
+	}
+	content := intotoEntry.IntotoObj.Content
+	h := sha256.Sum256(provenance)
+	if content == nil || content.Hash == nil ||
+		swag.StringValue(content.Hash.Value) != hex.EncodeToString(h[:]) {
+		return fmt.Errorf("%w: entry does not match the envelope", serrors.ErrorInvalidRekorEntry)
+	}
+	entryCert, err := extractCert(e)
+	if err != nil {
+		return err
+	}
+	if !entryCert.Equal(cert) {
+		return fmt.Errorf("%w: entry does not match the certificate", serrors.ErrorInvalidRekorEntry)
+	}
+	return nil
+}
+
+func extractCert(e *models.LogEntryAnon) (*x509.Certificate, error) {
+	intotoEntry, err := intotoLogEntry(e)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeyB64, err := intotoEntry.IntotoObj.PublicKey.MarshalText()
+	if err != nil {
+		return nil, err
+	}
 
 	publicKey, err := base64.StdEncoding.DecodeString(string(publicKeyB64))
 	if err != nil {
@@ -165,11 +301,13 @@ func intotoEntry(certPem, provenance []byte) (*intotod.V001Entry, error) {
 }
 
 // getUUIDsByArtifactDigest finds all entry UUIDs by the digest of the artifact binary.
-func getUUIDsByArtifactDigest(rClient *client.Rekor, artifactHash string) ([]string, error) {
+func getUUIDsByArtifactDigest(ctx context.Context, rClient *client.Rekor, artifactHash string) ([]string, error) {
 	// Use search index to find rekor entry UUIDs that match Subject Digest.
 	params := index.NewSearchIndexParams()
 	params.Query = &models.SearchIndex{Hash: fmt.Sprintf("sha256:%v", artifactHash)}
+	start := time.Now()
 	resp, err := rClient.Index.SearchIndex(params)
+	emitRekorRequest(ctx, "SearchIndex", start, err)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", serrors.ErrorRekorSearch, err.Error())
 	}
@@ -184,20 +322,71 @@ func getUUIDsByArtifactDigest(rClient *client.Rekor, artifactHash string) ([]str
 // GetValidSignedAttestationWithCert finds and validates the matching entry UUIDs with
 // the full intoto attestation.
 // The attestation generated by the slsa-github-generator libraries contain a signing certificate.
-func GetValidSignedAttestationWithCert(rClient *client.Rekor,
+func GetValidSignedAttestationWithCert(ctx context.Context, rClient *client.Rekor,
 	provenance []byte, trustedRoot *TrustedRoot,
+	verifierOpts *options.VerifierOpts,
 ) (*SignedAttestation, error) {
-	// Use intoto attestation to find rekor entry UUIDs.
-	params := entries.NewSearchLogQueryParams()
-	searchLogQuery := models.SearchLogQuery{}
 	certPem, err := envelope.GetCertFromEnvelope(provenance)
 	if err != nil {
 		return nil, fmt.Errorf("error getting certificate from provenance: %w", err)
 	}
 
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(certPem)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) != 1 {
+		return nil, fmt.Errorf("error unmarshaling certificate from pem")
+	}
+
+	cache := newTlogCache(verifierOpts)
+	uuid, rekorEntry := cache.envelopeEntry(provenance)
+	if rekorEntry != nil {
+		// Verify the cached entry as if it was returned by the search.
+		if _, err := verifyTlogEntry(ctx, *rekorEntry, true, trustedRoot.RekorPubKeys); err != nil ||
+			entryMatchesEnvelope(rekorEntry, provenance, certs[0]) != nil {
+			rekorEntry = nil
+		}
+	}
+	if rekorEntry == nil {
+		uuid, rekorEntry, err = searchTlogEntryWithCert(ctx, rClient, certPem, provenance, trustedRoot)
+		if err != nil {
+			return nil, err
+		}
+		cache.putEnvelopeEntry(ctx, provenance, uuid, rekorEntry)
+	}
+	url := fmt.Sprintf("%v/%v/%v", rekorAddr(verifierOpts), "api/v1/log/entries", uuid)
+	events.Emit(ctx, events.TlogEntryUsed{LogIndex: *rekorEntry.LogIndex, URL: url})
+
+	env, err := EnvelopeFromBytes(provenance)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedSignedAtt := &SignedAttestation{
+		SigningCert: certs[0],
+		Envelope:    env,
+		RekorEntry:  rekorEntry,
+	}
+
+	if err := verifySignedAttestation(proposedSignedAtt, trustedRoot, verifierOpts); err != nil {
+		return nil, err
+	}
+
+	return proposedSignedAtt, nil
+}
+
+// searchTlogEntryWithCert searches Rekor for the entry of the intoto
+// attestation and verifies it. It returns the UUID and the entry.
+func searchTlogEntryWithCert(ctx context.Context, rClient *client.Rekor,
+	certPem, provenance []byte, trustedRoot *TrustedRoot,
+) (string, *models.LogEntryAnon, error) {
+	// Use intoto attestation to find rekor entry UUIDs.
+	params := entries.NewSearchLogQueryParams()
+	searchLogQuery := models.SearchLogQuery{}
 	e, err := intotoEntry(certPem, provenance)
 	if err != nil {
-		return nil, fmt.Errorf("error creating intoto entry: %w", err)
+		return "", nil, fmt.Errorf("error creating intoto entry: %w", err)
 	}
 	entry := models.Intoto{
 		APIVersion: swag.String(e.APIVersion()),
@@ -206,65 +395,55 @@ func GetValidSignedAttestationWithCert(rClient *client.Rekor,
 	searchLogQuery.SetEntries([]models.ProposedEntry{&entry})
 
 	params.SetEntry(&searchLogQuery)
+	start := time.Now()
 	resp, err := rClient.Entries.SearchLogQuery(params)
+	emitRekorRequest(ctx, "SearchLogQuery", start, err)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", serrors.ErrorRekorSearch, err.Error())
+		return "", nil, fmt.Errorf("%w: %s", serrors.ErrorRekorSearch, err.Error())
 	}
 
 	if len(resp.GetPayload()) != 1 {
-		return nil, fmt.Errorf("%w: %s", serrors.ErrorRekorSearch, "no matching rekor entries")
+		return "", nil, fmt.Errorf("%w: %s", serrors.ErrorRekorSearch, "no matching rekor entries")
 	}
 
 	logEntry := resp.Payload[0]
 	var rekorEntry models.LogEntryAnon
+	var rekorUUID string
 	for uuid, e := range logEntry {
-		if _, err := verifyTlogEntry(context.Background(), e, true,
+		if _, err := verifyTlogEntry(ctx, e, true,
 			trustedRoot.RekorPubKeys); err != nil {
-			return nil, fmt.Errorf("error verifying tlog entry: %w", err)
+			return "", nil, fmt.Errorf("error verifying tlog entry: %w", err)
 		}
 		rekorEntry = e
-		url := fmt.Sprintf("%v/%v/%v", defaultRekorAddr, "api/v1/log/entries", uuid)
-		fmt.Fprintf(os.Stderr, "Verified signature against tlog entry index %d at URL: %s\n", *e.LogIndex, url)
-	}
-
-	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(certPem)
-	if err != nil {
-		return nil, err
-	}
-	if len(certs) != 1 {
-		return nil, fmt.Errorf("error unmarshaling certificate from pem")
-	}
-
-	env, err := EnvelopeFromBytes(provenance)
-	if err != nil {
-		return nil, err
-	}
-
-	proposedSignedAtt := &SignedAttestation{
-		SigningCert: certs[0],
-		Envelope:    env,
-		RekorEntry:  &rekorEntry,
+		rekorUUID = uuid
 	}
-
-	if err := verifySignedAttestation(proposedSignedAtt, trustedRoot); err != nil {
-		return nil, err
-	}
-
-	return proposedSignedAtt, nil
+	return rekorUUID, &rekorEntry, nil
 }
 
 // SearchValidSignedAttestation searches for a valid signing certificate using the Rekor
 // Redis search index by using the artifact digest.
 func SearchValidSignedAttestation(ctx context.Context, artifactHash string, provenance []byte,
-	rClient *client.Rekor, trustedRoot *TrustedRoot,
+	rClient *client.Rekor, trustedRoot *TrustedRoot, verifierOpts *options.VerifierOpts,
 ) (*SignedAttestation, error) {
-	// Get Rekor UUIDs by artifact digest.
-	uuids, err := getUUIDsByArtifactDigest(rClient, artifactHash)
+	env, err := EnvelopeFromBytes(provenance)
 	if err != nil {
 		return nil, err
 	}
 
-	env, err := EnvelopeFromBytes(provenance)
+	// Use the entry found for the envelope by a previous search, if it is
+	// still valid.
+	cache := newTlogCache(verifierOpts)
+	if uuid, entry := cache.envelopeEntry(provenance); entry != nil {
+		if proposedSignedAtt, err := verifyCachedEntry(ctx, env, entry,
+			trustedRoot, verifierOpts); err == nil {
+			url := fmt.Sprintf("%v/%v/%v", rekorAddr(verifierOpts), "api/v1/log/entries", uuid)
+			events.Emit(ctx, events.TlogEntryUsed{LogIndex: *entry.LogIndex, URL: url})
+			return proposedSignedAtt, nil
+		}
+	}
+
+	// Get Rekor UUIDs by artifact digest.
+	uuids, err := getUUIDsByArtifactDigest(ctx, rClient, artifactHash)
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +456,7 @@ func SearchValidSignedAttestation(ctx context.Context, artifactHash string, prov
 	//   * If all succeed, return the signing certificate.
 	var errs []string
 	for _, uuid := range uuids {
-		entry, err := verifyTlogEntryByUUID(ctx, rClient, uuid, trustedRoot)
+		entry, err := verifyTlogEntryByUUID(ctx, rClient, uuid, trustedRoot, cache)
 		if err != nil {
 			// this is unexpected, hold on to this error.
 			errs = append(errs, fmt.Sprintf("%s: verifying tlog entry %s", err, uuid))
@@ -297,7 +476,7 @@ func SearchValidSignedAttestation(ctx context.Context, artifactHash string, prov
 			RekorEntry:  entry,
 		}
 
-		err = verifySignedAttestation(proposedSignedAtt, trustedRoot)
+		err = verifySignedAttestation(proposedSignedAtt, trustedRoot, verifierOpts)
 		if errors.Is(err, serrors.ErrorInternal) {
 			// Return on an internal error
 			return nil, err
@@ -307,39 +486,66 @@ func SearchValidSignedAttestation(ctx context.Context, artifactHash string, prov
 		}
 
 		// success!
-		url := fmt.Sprintf("%v/%v/%v", defaultRekorAddr, "api/v1/log/entries", uuid)
-		fmt.Fprintf(os.Stderr, "Verified signature against tlog entry index %d at URL: %s\n", *entry.LogIndex, url)
+		cache.putEnvelopeEntry(ctx, provenance, uuid, entry)
+		url := fmt.Sprintf("%v/%v/%v", rekorAddr(verifierOpts), "api/v1/log/entries", uuid)
+		events.Emit(ctx, events.TlogEntryUsed{LogIndex: *entry.LogIndex, URL: url})
 		return proposedSignedAtt, nil
 	}
 
 	return nil, fmt.Errorf("%w: got unexpected errors %s", serrors.ErrorNoValidRekorEntries, strings.Join(errs, ", "))
 }
 
+// verifyCachedEntry verifies a cached Rekor entry for the envelope, as
+// SearchValidSignedAttestation verifies the entries returned by Rekor.
+func verifyCachedEntry(ctx context.Context, env *dsselib.Envelope, e *models.LogEntryAnon,
+	trustedRoot *TrustedRoot, verifierOpts *options.VerifierOpts,
+) (*SignedAttestation, error) {
+	entry, err := verifyTlogEntry(ctx, *e, true, trustedRoot.RekorPubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := extractCert(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedSignedAtt := &SignedAttestation{
+		Envelope:    env,
+		SigningCert: cert,
+		RekorEntry:  entry,
+	}
+	if err := verifySignedAttestation(proposedSignedAtt, trustedRoot, verifierOpts); err != nil {
+		return nil, err
+	}
+	return proposedSignedAtt, nil
+}
+
 // verifyAttestationSignature validates the signature on the attestation
 // given a certificate and a validated signature time from a verified
 // Rekor entry.
 // The certificate is verified up to Fulcio, the signature is validated
 // using the certificate, and the signature generation time is checked
 // to be within the certificate validity period.
-func verifySignedAttestation(signedAtt *SignedAttestation, trustedRoot *TrustedRoot) error {
+func verifySignedAttestation(signedAtt *SignedAttestation, trustedRoot *TrustedRoot,
+	verifierOpts *options.VerifierOpts,
+) error {
 	cert := signedAtt.SigningCert
 	attBytes, err := cjson.MarshalCanonical(signedAtt.Envelope)
 	if err != nil {
 		return err
 	}
-	signatureTimestamp := time.Unix(*signedAtt.RekorEntry.IntegratedTime, 0)
+	signatureTimes, err := verifiedSignatureTimes(signedAtt)
+	if err != nil {
+		return err
+	}
 
 	// 1. Verify certificate chain.
 	co := &cosign.CheckOpts{
 		RootCerts:         trustedRoot.FulcioRoot,
 		IntermediateCerts: trustedRoot.FulcioIntermediates,
-		Identities: []cosign.Identity{
-			{
-				Issuer:        certOidcIssuer,
-				SubjectRegExp: certSubjectRegexp,
-			},
-		},
-		CTLogPubKeys: trustedRoot.CTPubKeys,
+		Identities:        CertIdentities(verifierOpts),
+		CTLogPubKeys:      trustedRoot.CTPubKeys,
 	}
 	verifier, err := cosign.ValidateAndUnpackCert(signedAtt.SigningCert, co)
 	if err != nil {
@@ -353,8 +559,33 @@ func verifySignedAttestation(signedAtt *SignedAttestation, trustedRoot *TrustedR
 	}
 
 	// 3. Verify signature was creating during certificate validity period.
-	if err := cosign.CheckExpiry(cert, signatureTimestamp); err != nil {
-		return fmt.Errorf("%w: %s", serrors.ErrorInvalidSignature, err)
+	for _, t := range signatureTimes {
+		if err := cosign.CheckExpiry(cert, t); err != nil {
+			return fmt.Errorf("%w: %s", serrors.ErrorInvalidSignature, err)
+		}
 	}
 	return nil
 }
+
+// verifiedSignatureTimes returns the verified times at which the signature
+// existed: the integrated time of the Rekor entry, if its
+// SignedEntryTimestamp was verified, and the times of the verified
+// RFC 3161 timestamps.
+func verifiedSignatureTimes(signedAtt *SignedAttestation) ([]time.Time, error) {
+	var times []time.Time
+	if e := signedAtt.RekorEntry; hasVerifiedIntegratedTime(e) {
+		times = append(times, time.Unix(*e.IntegratedTime, 0))
+	}
+	times = append(times, signedAtt.Timestamps...)
+	if len(times) == 0 {
+		return nil, fmt.Errorf("%w: no verified signature time", serrors.ErrorInvalidSignature)
+	}
+	return times, nil
+}
+
+// hasVerifiedIntegratedTime returns whether the verified Rekor entry has
+// a SignedEntryTimestamp, which covers its integrated time.
+func hasVerifiedIntegratedTime(e *models.LogEntryAnon) bool {
+	return e != nil && e.IntegratedTime != nil &&
+		e.Verification != nil && len(e.Verification.SignedEntryTimestamp) > 0
+}
@@ -1,15 +1,19 @@
 package gha
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/runtime"
 	"github.com/google/go-cmp/cmp"
 	"github.com/sigstore/rekor/pkg/generated/client"
 	"github.com/sigstore/rekor/pkg/generated/client/index"
+	"github.com/sigstore/rekor/pkg/generated/models"
 
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
 )
 
 type searchResult struct {
@@ -81,10 +85,102 @@ func Test_GetRekorEntries(t *testing.T) {
 			var mClient client.Rekor
 			mClient.Index = &MockIndexClient{result: tt.res}
 
-			_, err := getUUIDsByArtifactDigest(&mClient, tt.artifactHash)
+			var requests []events.RekorRequest
+			ctx := events.WithObserver(context.Background(),
+				events.ObserverFunc(func(_ context.Context, e events.Event) {
+					if r, ok := e.(events.RekorRequest); ok {
+						requests = append(requests, r)
+					}
+				}))
+
+			_, err := getUUIDsByArtifactDigest(ctx, &mClient, tt.artifactHash)
 			if !errCmp(err, tt.expected) {
 				t.Errorf(cmp.Diff(err, tt.expected))
 			}
+
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 Rekor request, got %d", len(requests))
+			}
+			if requests[0].Operation != "SearchIndex" {
+				t.Errorf("unexpected operation: %s", requests[0].Operation)
+			}
+			if !errCmp(requests[0].Err, tt.res.err) {
+				t.Errorf(cmp.Diff(requests[0].Err, tt.res.err))
+			}
+		})
+	}
+}
+
+func Test_verifiedSignatureTimes(t *testing.T) {
+	t.Parallel()
+	integratedTime := int64(1680000000)
+	timestamp := time.Unix(1680000100, 0)
+
+	tests := []struct {
+		name      string
+		signedAtt *SignedAttestation
+		expected  []time.Time
+		err       error
+	}{
+		{
+			name: "integrated time",
+			signedAtt: &SignedAttestation{
+				RekorEntry: &models.LogEntryAnon{
+					IntegratedTime: &integratedTime,
+					Verification: &models.LogEntryAnonVerification{
+						SignedEntryTimestamp: []byte("set"),
+					},
+				},
+			},
+			expected: []time.Time{time.Unix(integratedTime, 0)},
+		},
+		{
+			name: "integrated time and timestamp",
+			signedAtt: &SignedAttestation{
+				RekorEntry: &models.LogEntryAnon{
+					IntegratedTime: &integratedTime,
+					Verification: &models.LogEntryAnonVerification{
+						SignedEntryTimestamp: []byte("set"),
+					},
+				},
+				Timestamps: []time.Time{timestamp},
+			},
+			expected: []time.Time{time.Unix(integratedTime, 0), timestamp},
+		},
+		{
+			name: "unverified integrated time",
+			signedAtt: &SignedAttestation{
+				RekorEntry: &models.LogEntryAnon{
+					IntegratedTime: &integratedTime,
+					Verification:   &models.LogEntryAnonVerification{},
+				},
+				Timestamps: []time.Time{timestamp},
+			},
+			expected: []time.Time{timestamp},
+		},
+		{
+			name: "no verified time",
+			signedAtt: &SignedAttestation{
+				RekorEntry: &models.LogEntryAnon{
+					IntegratedTime: &integratedTime,
+					Verification:   &models.LogEntryAnonVerification{},
+				},
+			},
+			err: serrors.ErrorInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			times, err := verifiedSignatureTimes(tt.signedAtt)
+			if !errCmp(err, tt.err) {
+				t.Errorf(cmp.Diff(err, tt.err))
+			}
+			if diff := cmp.Diff(tt.expected, times); diff != "" {
+				t.Errorf("unexpected times (-want +got): \n%s", diff)
+			}
 		})
 	}
 }
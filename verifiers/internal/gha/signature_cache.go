@@ -0,0 +1,109 @@
+package gha
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+// signatureCache shares the verification of the signature of identical
+// provenance between the verifications of a batch.
+type signatureCache struct {
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*signatureCacheEntry
+}
+
+type signatureCacheEntry struct {
+	// done is closed when the verification is complete.
+	done chan struct{}
+	att  *SignedAttestation
+	err  error
+}
+
+type signatureCacheKey struct{}
+
+// WithSignatureCache returns a context in which the signature and the
+// transparency log entry of identical provenance are verified only once
+// per verifier options.
+func WithSignatureCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, signatureCacheKey{}, &signatureCache{
+		entries: make(map[[sha256.Size]byte]*signatureCacheEntry),
+	})
+}
+
+// verifySignatureOnce returns the result of verify for the provenance.
+// It calls verify only once per provenance, verifier options and searched
+// artifact if the context has a signature cache. searchHash is the artifact
+// hash the Rekor entry of the provenance is searched by, or empty if the
+// provenance has its certificate. Failed verifications are not cached:
+// a verification that fails for one artifact may succeed for another.
+func verifySignatureOnce(ctx context.Context, provenance []byte,
+	searchHash string, verifierOpts *options.VerifierOpts,
+	verify func() (*SignedAttestation, error),
+) (*SignedAttestation, error) {
+	c, ok := ctx.Value(signatureCacheKey{}).(*signatureCache)
+	if !ok {
+		return verify()
+	}
+
+	key, err := signatureCacheKeyOf(provenance, searchHash, verifierOpts)
+	if err != nil {
+		return verify()
+	}
+	for {
+		c.mu.Lock()
+		e, ok := c.entries[key]
+		if !ok {
+			e = &signatureCacheEntry{done: make(chan struct{})}
+			c.entries[key] = e
+			c.mu.Unlock()
+
+			e.att, e.err = verify()
+			if e.err != nil {
+				c.mu.Lock()
+				delete(c.entries, key)
+				c.mu.Unlock()
+			}
+			close(e.done)
+			return e.att, e.err
+		}
+		c.mu.Unlock()
+
+		// Wait for the verification in progress. If it fails, verify
+		// again with our own function.
+		<-e.done
+		if e.err == nil {
+			return e.att, nil
+		}
+	}
+}
+
+// signatureCacheKeyOf returns the key of the verification of the provenance
+// with the verifier options, for the artifact hash it is searched by.
+func signatureCacheKeyOf(provenance []byte, searchHash string,
+	verifierOpts *options.VerifierOpts,
+) ([sha256.Size]byte, error) {
+	var opts options.VerifierOpts
+	if verifierOpts != nil {
+		opts = *verifierOpts
+	}
+	// The certificate pools have no exported fields, so they are
+	// compared by identity.
+	pools := fmt.Sprintf("%p %p", opts.FulcioRoots, opts.FulcioIntermediates)
+	opts.FulcioRoots, opts.FulcioIntermediates = nil, nil
+
+	b, err := json.Marshal(struct {
+		Provenance   []byte
+		SearchHash   string
+		VerifierOpts options.VerifierOpts
+		CertPools    string
+	}{provenance, searchHash, opts, pools})
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
@@ -0,0 +1,168 @@
+package gha
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+func Test_verifySignatureOnce(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		err           error
+		expectedCalls int
+	}{
+		{
+			name:          "no cache",
+			ctx:           context.Background(),
+			expectedCalls: 4,
+		},
+		{
+			name:          "cache",
+			ctx:           WithSignatureCache(context.Background()),
+			expectedCalls: 2,
+		},
+		{
+			name:          "failure not cached",
+			ctx:           WithSignatureCache(context.Background()),
+			err:           serrors.ErrorInvalidSignature,
+			expectedCalls: 4,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			att := &SignedAttestation{}
+			verify := func() (*SignedAttestation, error) {
+				calls++
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return att, nil
+			}
+
+			for _, provenance := range []string{"a", "b", "a", "b"} {
+				got, err := verifySignatureOnce(tt.ctx, []byte(provenance), "", nil, verify)
+				if !errors.Is(err, tt.err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if tt.err == nil && got != att {
+					t.Errorf("unexpected attestation: %v", got)
+				}
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d verifications, got %d", tt.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func Test_verifySignatureOnce_batch(t *testing.T) {
+	t.Parallel()
+	ctx := WithSignatureCache(context.Background())
+
+	// The Rekor search of provenance without a certificate depends on the artifact.
+	calls := 0
+	att := &SignedAttestation{}
+	verify := func(artifactHash string) func() (*SignedAttestation, error) {
+		return func() (*SignedAttestation, error) {
+			calls++
+			if artifactHash == "bad" {
+				return nil, serrors.ErrorRekorSearch
+			}
+			return att, nil
+		}
+	}
+
+	tests := []struct {
+		artifactHash string
+		expected     error
+	}{
+		{artifactHash: "bad", expected: serrors.ErrorRekorSearch},
+		{artifactHash: "good"},
+		{artifactHash: "good"},
+		{artifactHash: "other"},
+		{artifactHash: "bad", expected: serrors.ErrorRekorSearch},
+	}
+	for _, tt := range tests {
+		got, err := verifySignatureOnce(ctx, []byte("a"), tt.artifactHash, nil, verify(tt.artifactHash))
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("%s: unexpected error: %v", tt.artifactHash, err)
+		}
+		if tt.expected == nil && got != att {
+			t.Errorf("%s: unexpected attestation: %v", tt.artifactHash, got)
+		}
+	}
+	// The successful verification is shared by the items with the same artifact.
+	if calls != 4 {
+		t.Errorf("expected 4 verifications, got %d", calls)
+	}
+}
+
+func Test_signatureCacheKeyOf(t *testing.T) {
+	t.Parallel()
+	roots, otherRoots := x509.NewCertPool(), x509.NewCertPool()
+	key := func(provenance, searchHash string, verifierOpts *options.VerifierOpts) [sha256.Size]byte {
+		k, err := signatureCacheKeyOf([]byte(provenance), searchHash, verifierOpts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+	base := key("a", "", &options.VerifierOpts{FulcioRoots: roots})
+
+	tests := []struct {
+		name  string
+		key   [sha256.Size]byte
+		equal bool
+	}{
+		{
+			name:  "same options",
+			key:   key("a", "", &options.VerifierOpts{FulcioRoots: roots}),
+			equal: true,
+		},
+		{
+			name: "other provenance",
+			key:  key("b", "", &options.VerifierOpts{FulcioRoots: roots}),
+		},
+		{
+			name: "searched artifact",
+			key:  key("a", "abcd", &options.VerifierOpts{FulcioRoots: roots}),
+		},
+		{
+			name: "other issuers",
+			key: key("a", "", &options.VerifierOpts{
+				FulcioRoots:     roots,
+				CertOIDCIssuers: []string{"https://gitlab.com"},
+			}),
+		},
+		{
+			name: "other roots",
+			key:  key("a", "", &options.VerifierOpts{FulcioRoots: otherRoots}),
+		},
+		{
+			name: "no options",
+			key:  key("a", "", nil),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if (tt.key == base) != tt.equal {
+				t.Errorf("unexpected key equality: %v", !tt.equal)
+			}
+		})
+	}
+}
@@ -35,7 +35,12 @@ func ProvenanceFromEnvelope(env *dsselib.Envelope) (iface.Provenance, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
 	}
+	return ProvenanceFromStatement(pyld)
+}
 
+// ProvenanceFromStatement returns a Provenance instance for the given
+// in-toto statement.
+func ProvenanceFromStatement(pyld []byte) (iface.Provenance, error) {
 	// Get the predicateType, a required field.
 	pred := struct {
 		PredicateType string `json:"predicateType"`
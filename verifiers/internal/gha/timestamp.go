@@ -0,0 +1,81 @@
+package gha
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	bundle_v1 "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/timestamp-authority/pkg/verification"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+)
+
+// verifyBundleTimestamps verifies the RFC 3161 timestamps of the bundle
+// against the trusted timestamp authorities, and returns their times.
+// The timestamps are over a signature of the DSSE envelope.
+// The roots fetched from TUF have no timestamp authority, so without one
+// the timestamps are ignored if the integrated time of the Rekor entry
+// is verified by its SignedEntryTimestamp.
+func verifyBundleTimestamps(bundle *bundle_v1.Bundle, rekorEntry *models.LogEntryAnon,
+	trustedRoot *TrustedRoot,
+) ([]time.Time, error) {
+	timestamps := bundle.GetVerificationMaterial().GetTimestampVerificationData().GetRfc3161Timestamps()
+	if len(timestamps) == 0 {
+		return nil, nil
+	}
+	if len(trustedRoot.TimestampAuthorities) == 0 && hasVerifiedIntegratedTime(rekorEntry) {
+		return nil, nil
+	}
+
+	var signatures [][]byte
+	for _, sig := range bundle.GetDsseEnvelope().GetSignatures() {
+		signatures = append(signatures, sig.GetSig())
+	}
+
+	times := make([]time.Time, 0, len(timestamps))
+	for _, ts := range timestamps {
+		t, err := verifyTimestamp(ts.GetSignedTimestamp(), signatures,
+			trustedRoot.TimestampAuthorities)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// verifyTimestamp verifies a DER-encoded RFC 3161 timestamp response over
+// one of the signatures, issued by one of the timestamp authorities
+// during its validity period. It returns the time of the timestamp.
+func verifyTimestamp(response []byte, signatures [][]byte, tsas []TimestampAuthority) (time.Time, error) {
+	if len(tsas) == 0 {
+		return time.Time{}, fmt.Errorf("%w: no trusted timestamp authority", serrors.ErrorInvalidTimestamp)
+	}
+
+	lastErr := errors.New("no signature")
+	for _, tsa := range tsas {
+		opts := verification.VerifyOpts{
+			TSACertificate: tsa.Leaf,
+			Intermediates:  tsa.Intermediates,
+			Roots:          []*x509.Certificate{tsa.Root},
+		}
+		for _, sig := range signatures {
+			ts, err := verification.VerifyTimestampResponse(response, bytes.NewReader(sig), opts)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if (!tsa.ValidFrom.IsZero() && ts.Time.Before(tsa.ValidFrom)) ||
+				(!tsa.ValidUntil.IsZero() && ts.Time.After(tsa.ValidUntil)) {
+				lastErr = fmt.Errorf("timestamp at %v outside the validity period of the authority", ts.Time)
+				continue
+			}
+			return ts.Time, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %s", serrors.ErrorInvalidTimestamp, lastErr)
+}
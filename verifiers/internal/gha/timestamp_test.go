@@ -0,0 +1,184 @@
+package gha
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/digitorus/timestamp"
+	"github.com/google/go-cmp/cmp"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+)
+
+// testTSA issues RFC 3161 timestamps.
+type testTSA struct {
+	key  *ecdsa.PrivateKey
+	leaf *x509.Certificate
+	root *x509.Certificate
+}
+
+func newTestTSA(t *testing.T) *testTSA {
+	t.Helper()
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test TSA root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	root := createTestCert(t, rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+
+	// RFC 3161 requires a critical extended key usage extension.
+	eku, err := asn1.Marshal([]asn1.ObjectIdentifier{{1, 3, 6, 1, 5, 5, 7, 3, 8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := createTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test TSA"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{2, 5, 29, 37}, Critical: true, Value: eku},
+		},
+	}, root, &key.PublicKey, rootKey)
+
+	return &testTSA{key: key, leaf: leaf, root: root}
+}
+
+func createTestCert(t *testing.T, template, parent *x509.Certificate,
+	pub crypto.PublicKey, priv crypto.Signer,
+) *x509.Certificate {
+	t.Helper()
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func (tsa *testTSA) authority() TimestampAuthority {
+	return TimestampAuthority{Leaf: tsa.leaf, Root: tsa.root}
+}
+
+// timestamp returns a timestamp response over the message at time tm.
+func (tsa *testTSA) timestamp(t *testing.T, message []byte, tm time.Time) []byte {
+	t.Helper()
+	h := sha256.Sum256(message)
+	ts := timestamp.Timestamp{
+		HashAlgorithm:     crypto.SHA256,
+		HashedMessage:     h[:],
+		Time:              tm,
+		Policy:            asn1.ObjectIdentifier{1, 2, 3},
+		AddTSACertificate: true,
+	}
+	response, err := ts.CreateResponse(tsa.leaf, tsa.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+func Test_verifyTimestamp(t *testing.T) {
+	t.Parallel()
+	tsa, otherTSA := newTestTSA(t), newTestTSA(t)
+	signature := []byte("signature")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		response   []byte
+		signatures [][]byte
+		tsas       []TimestampAuthority
+		expected   error
+	}{
+		{
+			name:       "valid timestamp",
+			response:   tsa.timestamp(t, signature, now),
+			signatures: [][]byte{signature},
+			tsas:       []TimestampAuthority{tsa.authority()},
+		},
+		{
+			name:       "second signature and authority",
+			response:   tsa.timestamp(t, signature, now),
+			signatures: [][]byte{[]byte("other signature"), signature},
+			tsas:       []TimestampAuthority{otherTSA.authority(), tsa.authority()},
+		},
+		{
+			name:       "other signature",
+			response:   tsa.timestamp(t, []byte("other signature"), now),
+			signatures: [][]byte{signature},
+			tsas:       []TimestampAuthority{tsa.authority()},
+			expected:   serrors.ErrorInvalidTimestamp,
+		},
+		{
+			name:       "untrusted authority",
+			response:   otherTSA.timestamp(t, signature, now),
+			signatures: [][]byte{signature},
+			tsas:       []TimestampAuthority{tsa.authority()},
+			expected:   serrors.ErrorInvalidTimestamp,
+		},
+		{
+			name:       "no trusted authority",
+			response:   tsa.timestamp(t, signature, now),
+			signatures: [][]byte{signature},
+			expected:   serrors.ErrorInvalidTimestamp,
+		},
+		{
+			name:       "authority no longer valid",
+			response:   tsa.timestamp(t, signature, now),
+			signatures: [][]byte{signature},
+			tsas: []TimestampAuthority{{
+				Leaf:       tsa.leaf,
+				Root:       tsa.root,
+				ValidUntil: now.Add(-time.Minute),
+			}},
+			expected: serrors.ErrorInvalidTimestamp,
+		},
+		{
+			name:       "invalid response",
+			response:   []byte("response"),
+			signatures: [][]byte{signature},
+			tsas:       []TimestampAuthority{tsa.authority()},
+			expected:   serrors.ErrorInvalidTimestamp,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tm, err := verifyTimestamp(tt.response, tt.signatures, tt.tsas)
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+			if err == nil && !tm.Equal(now) {
+				t.Errorf("unexpected time: %v", tm)
+			}
+		})
+	}
+}
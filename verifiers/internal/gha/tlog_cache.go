@@ -0,0 +1,149 @@
+package gha
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/sharding"
+
+	"github.com/slsa-framework/slsa-verifier/v2/events"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+// uuidRegexp matches the UUIDs of Rekor entries, used as file names.
+var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// cacheUUID returns the UUID of a Rekor entry ID, which may include
+// the tree ID, or false if it is invalid.
+func cacheUUID(id string) (string, bool) {
+	uuid, err := sharding.GetUUIDFromIDString(id)
+	if err != nil || !uuidRegexp.MatchString(uuid) {
+		return "", false
+	}
+	return uuid, true
+}
+
+// tlogCache is an on-disk cache of verified Rekor entries, keyed by UUID
+// and by the hash of the envelope they were found for. The cache is not
+// trusted: the callers verify the entries read from it, offline, as if
+// they had been fetched from Rekor.
+//
+// A nil *tlogCache is a valid cache that caches nothing.
+type tlogCache struct {
+	dir string
+}
+
+// newTlogCache returns the cache configured in the verifier options, or nil.
+func newTlogCache(verifierOpts *options.VerifierOpts) *tlogCache {
+	if verifierOpts == nil || verifierOpts.TlogCacheDir == "" {
+		return nil
+	}
+	return &tlogCache{dir: verifierOpts.TlogCacheDir}
+}
+
+func (c *tlogCache) entryPath(uuid string) string {
+	return filepath.Join(c.dir, "entries", uuid+".json")
+}
+
+func (c *tlogCache) envelopePath(provenance []byte) string {
+	h := sha256.Sum256(provenance)
+	return filepath.Join(c.dir, "envelopes", hex.EncodeToString(h[:]))
+}
+
+// entry returns the cached entry with the UUID, if any.
+func (c *tlogCache) entry(id string) *models.LogEntryAnon {
+	if c == nil {
+		return nil
+	}
+	uuid, ok := cacheUUID(id)
+	if !ok {
+		return nil
+	}
+	b, err := os.ReadFile(c.entryPath(uuid))
+	if err != nil {
+		return nil
+	}
+	var e models.LogEntryAnon
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil
+	}
+	return &e
+}
+
+// envelopeEntry returns the UUID and the cached entry found for the
+// envelope, if any.
+func (c *tlogCache) envelopeEntry(provenance []byte) (string, *models.LogEntryAnon) {
+	if c == nil {
+		return "", nil
+	}
+	b, err := os.ReadFile(c.envelopePath(provenance))
+	if err != nil {
+		return "", nil
+	}
+	uuid := strings.TrimSpace(string(b))
+	e := c.entry(uuid)
+	if e == nil {
+		return "", nil
+	}
+	return uuid, e
+}
+
+// putEntry caches a verified entry. Failures are reported as warnings.
+func (c *tlogCache) putEntry(ctx context.Context, id string, e *models.LogEntryAnon) {
+	if c == nil {
+		return
+	}
+	uuid, ok := cacheUUID(id)
+	if !ok {
+		events.Emit(ctx, events.Warning{Message: fmt.Sprintf("not caching tlog entry with invalid UUID %q", id)})
+		return
+	}
+	b, err := json.Marshal(e)
+	if err == nil {
+		err = writeFileAtomic(c.entryPath(uuid), b)
+	}
+	if err != nil {
+		events.Emit(ctx, events.Warning{Message: fmt.Sprintf("caching tlog entry %s: %v", uuid, err)})
+	}
+}
+
+// putEnvelopeEntry caches a verified entry found for the envelope.
+func (c *tlogCache) putEnvelopeEntry(ctx context.Context, provenance []byte, id string, e *models.LogEntryAnon) {
+	if c == nil {
+		return
+	}
+	c.putEntry(ctx, id, e)
+	if err := writeFileAtomic(c.envelopePath(provenance), []byte(id)); err != nil {
+		events.Emit(ctx, events.Warning{Message: fmt.Sprintf("caching tlog entry %s: %v", id, err)})
+	}
+}
+
+// writeFileAtomic writes a file, so that concurrent readers never see
+// a partially written file.
+func writeFileAtomic(path string, b []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
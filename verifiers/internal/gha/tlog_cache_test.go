@@ -0,0 +1,191 @@
+package gha
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+const testUUID = "362f8ecba72f4326972bc321d658ba3c3f93ba5e8b4d4c5e4d6e4b2e8fe8e2b1"
+
+func Test_tlogCache(t *testing.T) {
+	t.Parallel()
+
+	var warnings []events.Event
+	ctx := events.WithObserver(context.Background(),
+		events.ObserverFunc(func(_ context.Context, e events.Event) {
+			warnings = append(warnings, e)
+		}))
+
+	// A nil cache caches nothing.
+	var nilCache *tlogCache
+	nilCache.putEnvelopeEntry(ctx, []byte("envelope"), testUUID, &models.LogEntryAnon{})
+	if e := nilCache.entry(testUUID); e != nil {
+		t.Errorf("unexpected entry in nil cache: %v", e)
+	}
+	if newTlogCache(&options.VerifierOpts{}) != nil {
+		t.Errorf("expected no cache without a directory")
+	}
+
+	dir := t.TempDir()
+	cache := newTlogCache(&options.VerifierOpts{TlogCacheDir: dir})
+	entry := &models.LogEntryAnon{
+		Body:           "Ym9keQ==",
+		IntegratedTime: swag.Int64(1680000000),
+		LogID:          swag.String("c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d"),
+		LogIndex:       swag.Int64(42),
+	}
+
+	// Entry IDs include the tree ID before the UUID.
+	cache.putEnvelopeEntry(ctx, []byte("envelope"), "24296fb24b8ad77a"+testUUID, entry)
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+
+	uuid, got := cache.envelopeEntry([]byte("envelope"))
+	if diff := cmp.Diff(entry, got); diff != "" {
+		t.Errorf("unexpected entry (-want +got): \n%s", diff)
+	}
+	if got := cache.entry(uuid); got == nil {
+		t.Errorf("expected entry %s", uuid)
+	}
+	if _, got := cache.envelopeEntry([]byte("other envelope")); got != nil {
+		t.Errorf("unexpected entry for other envelope: %v", got)
+	}
+
+	// Invalid UUIDs are not used as file names.
+	cache.putEntry(ctx, "../"+testUUID, entry)
+	if len(warnings) != 1 {
+		t.Errorf("expected a warning, got %v", warnings)
+	}
+
+	// Corrupted entries are ignored.
+	if err := os.WriteFile(filepath.Join(dir, "entries", testUUID+".json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := cache.entry(testUUID); got != nil {
+		t.Errorf("unexpected corrupted entry: %v", got)
+	}
+}
+
+// testCert returns a self-signed certificate in the PEM format.
+func testCert(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPem, err := cryptoutils.MarshalCertificateToPEM(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPem
+}
+
+// testIntotoLogEntry returns a Rekor entry of an intoto attestation.
+func testIntotoLogEntry(t *testing.T, provenance, certPem []byte) *models.LogEntryAnon {
+	t.Helper()
+	h := sha256.Sum256(provenance)
+	publicKey := strfmt.Base64(certPem)
+	body, err := json.Marshal(&models.Intoto{
+		APIVersion: swag.String("0.0.1"),
+		Spec: models.IntotoV001Schema{
+			Content: &models.IntotoV001SchemaContent{
+				Hash: &models.IntotoV001SchemaContentHash{
+					Algorithm: swag.String(models.IntotoV001SchemaContentHashAlgorithmSha256),
+					Value:     swag.String(hex.EncodeToString(h[:])),
+				},
+			},
+			PublicKey: &publicKey,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &models.LogEntryAnon{Body: base64.StdEncoding.EncodeToString(body)}
+}
+
+func Test_entryMatchesEnvelope(t *testing.T) {
+	t.Parallel()
+
+	certPem := testCert(t)
+	otherCertPem := testCert(t)
+	entry := testIntotoLogEntry(t, []byte("envelope"), certPem)
+
+	tests := []struct {
+		name       string
+		provenance []byte
+		certPem    []byte
+		expected   error
+	}{
+		{
+			name:       "match",
+			provenance: []byte("envelope"),
+			certPem:    certPem,
+		},
+		{
+			name:       "other envelope",
+			provenance: []byte("other envelope"),
+			certPem:    certPem,
+			expected:   serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name:       "other certificate",
+			provenance: []byte("envelope"),
+			certPem:    otherCertPem,
+			expected:   serrors.ErrorInvalidRekorEntry,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			certs, err := cryptoutils.UnmarshalCertificatesFromPEM(tt.certPem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = entryMatchesEnvelope(entry, tt.provenance, certs[0])
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
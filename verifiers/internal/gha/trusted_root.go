@@ -3,12 +3,20 @@ package gha
 import (
 	"context"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"sync/atomic"
+	"time"
 
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/fulcio"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
+	trustroot_v1 "github.com/sigstore/protobuf-specs/gen/pb-go/trustroot/v1"
+	"github.com/sigstore/sigstore/pkg/tuf"
+	"google.golang.org/protobuf/encoding/protojson"
+
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
 )
 
 // TrustedRoot struct that holds the verification material necessary
@@ -25,6 +33,22 @@ type TrustedRoot struct {
 
 	// Certificate pool for Fulcio intermediates
 	FulcioIntermediates *x509.CertPool
+
+	// TimestampAuthorities are the trusted RFC 3161 timestamp authorities.
+	// They are only set by trusted root files.
+	TimestampAuthorities []TimestampAuthority
+}
+
+// TimestampAuthority holds the certificates of a timestamp authority.
+type TimestampAuthority struct {
+	// Leaf is the certificate signing the timestamps. It may be nil
+	// if the timestamps include it.
+	Leaf          *x509.Certificate
+	Intermediates []*x509.Certificate
+	Root          *x509.Certificate
+	// ValidFrom and ValidUntil bound the period during which the
+	// authority is trusted. Zero values are unbounded.
+	ValidFrom, ValidUntil time.Time
 }
 
 func getTrustedRoot(ctx context.Context) (*TrustedRoot, error) {
@@ -58,6 +82,146 @@ func getTrustedRoot(ctx context.Context) (*TrustedRoot, error) {
 	}, nil
 }
 
+// TrustedRootFromFile reads a Sigstore trusted_root.json file and returns the
+// corresponding TrustedRoot. This allows verification without fetching the
+// verification material from TUF.
+// See https://github.com/sigstore/protobuf-specs/blob/main/protos/sigstore_trustroot.proto.
+func TrustedRootFromFile(path string) (*TrustedRoot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidTrustedRoot, err)
+	}
+	return TrustedRootFromJSON(content)
+}
+
+// TrustedRootFromJSON parses the content of a Sigstore trusted_root.json file.
+func TrustedRootFromJSON(content []byte) (*TrustedRoot, error) {
+	var pbRoot trustroot_v1.TrustedRoot
+	if err := protojson.Unmarshal(content, &pbRoot); err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidTrustedRoot, err)
+	}
+
+	rekorPubKeys, err := transparencyLogPubKeys(pbRoot.GetTlogs())
+	if err != nil {
+		return nil, err
+	}
+	if len(rekorPubKeys.Keys) == 0 {
+		return nil, fmt.Errorf("%w: no transparency log keys", serrors.ErrorInvalidTrustedRoot)
+	}
+
+	ctPubKeys, err := transparencyLogPubKeys(pbRoot.GetCtlogs())
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	for _, ca := range pbRoot.GetCertificateAuthorities() {
+		// The chain is ordered from the intermediate(s) to the root.
+		chain := ca.GetCertChain().GetCertificates()
+		if len(chain) == 0 {
+			return nil, fmt.Errorf("%w: empty certificate chain for %s",
+				serrors.ErrorInvalidTrustedRoot, ca.GetUri())
+		}
+		for i, c := range chain {
+			cert, err := x509.ParseCertificate(c.GetRawBytes())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidTrustedRoot, err)
+			}
+			if i == len(chain)-1 {
+				roots.AddCert(cert)
+			} else {
+				intermediates.AddCert(cert)
+			}
+		}
+	}
+
+	timestampAuthorities, err := timestampAuthorities(pbRoot.GetTimestampAuthorities())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrustedRoot{
+		FulcioRoot:           roots,
+		FulcioIntermediates:  intermediates,
+		RekorPubKeys:         &rekorPubKeys,
+		CTPubKeys:            &ctPubKeys,
+		TimestampAuthorities: timestampAuthorities,
+	}, nil
+}
+
+// timestampAuthorities converts the timestamp authorities of a trusted root.
+func timestampAuthorities(cas []*trustroot_v1.CertificateAuthority) ([]TimestampAuthority, error) {
+	var tsas []TimestampAuthority
+	for _, ca := range cas {
+		// The chain is ordered from the leaf to the root.
+		var chain []*x509.Certificate
+		for _, c := range ca.GetCertChain().GetCertificates() {
+			cert, err := x509.ParseCertificate(c.GetRawBytes())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidTrustedRoot, err)
+			}
+			chain = append(chain, cert)
+		}
+		if len(chain) == 0 {
+			return nil, fmt.Errorf("%w: empty certificate chain for %s",
+				serrors.ErrorInvalidTrustedRoot, ca.GetUri())
+		}
+
+		tsa := TimestampAuthority{
+			Root: chain[len(chain)-1],
+		}
+		if len(chain) > 1 {
+			tsa.Leaf = chain[0]
+			tsa.Intermediates = chain[1 : len(chain)-1]
+		}
+		if start := ca.GetValidFor().GetStart(); start != nil {
+			tsa.ValidFrom = start.AsTime()
+		}
+		if end := ca.GetValidFor().GetEnd(); end != nil {
+			tsa.ValidUntil = end.AsTime()
+		}
+		tsas = append(tsas, tsa)
+	}
+	return tsas, nil
+}
+
+// transparencyLogPubKeys converts the transparency log instances of a trusted root
+// into the key map used by cosign, indexed by log ID.
+func transparencyLogPubKeys(tlogs []*trustroot_v1.TransparencyLogInstance) (
+	cosign.TrustedTransparencyLogPubKeys, error,
+) {
+	pubKeys := cosign.NewTrustedTransparencyLogPubKeys()
+	for _, tlog := range tlogs {
+		pubKey, err := x509.ParsePKIXPublicKey(tlog.GetPublicKey().GetRawBytes())
+		if err != nil {
+			return pubKeys, fmt.Errorf("%w: %s: %s", serrors.ErrorInvalidTrustedRoot,
+				tlog.GetBaseUrl(), err)
+		}
+		logID, err := cosign.GetTransparencyLogID(pubKey)
+		if err != nil {
+			return pubKeys, fmt.Errorf("%w: %s: %s", serrors.ErrorInvalidTrustedRoot,
+				tlog.GetBaseUrl(), err)
+		}
+		if keyID := tlog.GetLogId().GetKeyId(); len(keyID) > 0 &&
+			hex.EncodeToString(keyID) != logID {
+			return pubKeys, fmt.Errorf("%w: %s: log ID does not match public key",
+				serrors.ErrorInvalidTrustedRoot, tlog.GetBaseUrl())
+		}
+
+		status := tuf.Active
+		if end := tlog.GetPublicKey().GetValidFor().GetEnd(); end != nil &&
+			end.AsTime().Before(time.Now()) {
+			status = tuf.Expired
+		}
+		pubKeys.Keys[logID] = cosign.TransparencyLogPubKey{
+			PubKey: pubKey,
+			Status: status,
+		}
+	}
+	return pubKeys, nil
+}
+
 // Cache the TUF roots to reduce traffic and read contention on the cached file.
 var manager atomic.Value
 
@@ -74,3 +238,46 @@ func TrustedRootSingleton(ctx context.Context) (*TrustedRoot, error) {
 	manager.Store(trustedRoot)
 	return trustedRoot, nil
 }
+
+// TrustedRootLoaded returns true if the trusted root has been loaded,
+// either by TrustedRootSingleton or SetTrustedRoot.
+func TrustedRootLoaded() bool {
+	return manager.Load() != nil
+}
+
+// SetTrustedRoot replaces the cached trusted root. Subsequent calls to
+// TrustedRootSingleton return the given root instead of fetching it from TUF.
+func SetTrustedRoot(trustedRoot *TrustedRoot) {
+	manager.Store(trustedRoot)
+}
+
+// TrustedRootWithOpts returns the trusted root to use with the given verifier
+// options. The trusted root set in the options takes precedence over the
+// cached one, and the Fulcio certificate pools set in the options take
+// precedence over the ones in the trusted root.
+func TrustedRootWithOpts(ctx context.Context, verifierOpts *options.VerifierOpts) (*TrustedRoot, error) {
+	var trustedRoot *TrustedRoot
+	var err error
+	if verifierOpts != nil && len(verifierOpts.TrustedRoot) > 0 {
+		trustedRoot, err = TrustedRootFromJSON(verifierOpts.TrustedRoot)
+	} else {
+		trustedRoot, err = TrustedRootSingleton(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if verifierOpts == nil ||
+		(verifierOpts.FulcioRoots == nil && verifierOpts.FulcioIntermediates == nil) {
+		return trustedRoot, nil
+	}
+
+	// Copy the cached root so that it is not modified.
+	root := *trustedRoot
+	if verifierOpts.FulcioRoots != nil {
+		root.FulcioRoot = verifierOpts.FulcioRoots
+	}
+	if verifierOpts.FulcioIntermediates != nil {
+		root.FulcioIntermediates = verifierOpts.FulcioIntermediates
+	}
+	return &root, nil
+}
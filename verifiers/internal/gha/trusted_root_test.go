@@ -0,0 +1,239 @@
+package gha
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+func Test_TrustedRootFromJSON(t *testing.T) {
+	t.Parallel()
+
+	validContent, err := os.ReadFile("./testdata/trusted_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Use the Fulcio certificate chain as a timestamp authority chain.
+	var root map[string]interface{}
+	if err := json.Unmarshal(validContent, &root); err != nil {
+		t.Fatal(err)
+	}
+	root["timestampAuthorities"] = root["certificateAuthorities"]
+	tsaContent, err := json.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		content  []byte
+		rekorIDs []string
+		ctIDs    []string
+		tsas     int
+		expected error
+	}{
+		{
+			name:     "valid trusted root",
+			content:  validContent,
+			rekorIDs: []string{"c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d"},
+			ctIDs:    []string{"dd3d306ac6c7113263191e1c99673702a24a5eb8de3cadff878a72802f29ee8e"},
+		},
+		{
+			name:     "timestamp authorities",
+			content:  tsaContent,
+			rekorIDs: []string{"c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d"},
+			tsas:     len(root["certificateAuthorities"].([]interface{})),
+		},
+		{
+			name:     "invalid json",
+			content:  []byte(`{"mediaType": `),
+			expected: serrors.ErrorInvalidTrustedRoot,
+		},
+		{
+			name:     "no tlogs",
+			content:  []byte(`{"mediaType": "application/vnd.dev.sigstore.trustedroot+json;version=0.1"}`),
+			expected: serrors.ErrorInvalidTrustedRoot,
+		},
+		{
+			name: "mismatch log ID",
+			content: []byte(`{"tlogs": [{
+				"baseUrl": "https://rekor.sigstore.dev",
+				"publicKey": {"rawBytes": "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE2G2Y+2tabdTV5BcGiBIx0a9fAFwrkBbmLSGtks4L3qX6yYY0zufBnhC8Ur/iy55GhWP/9A/bY2LhC30M9+RYtw=="},
+				"logId": {"keyId": "3T0wasbHETJjGR4cmWc3AqJKXrjePK3/h4pygC8p7o4="}
+			}]}`),
+			expected: serrors.ErrorInvalidTrustedRoot,
+		},
+		{
+			name: "invalid public key",
+			content: []byte(`{"tlogs": [{
+				"baseUrl": "https://rekor.sigstore.dev",
+				"publicKey": {"rawBytes": "aW52YWxpZA=="}
+			}]}`),
+			expected: serrors.ErrorInvalidTrustedRoot,
+		},
+		{
+			name: "empty certificate chain",
+			content: []byte(`{"tlogs": [{
+				"baseUrl": "https://rekor.sigstore.dev",
+				"publicKey": {"rawBytes": "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE2G2Y+2tabdTV5BcGiBIx0a9fAFwrkBbmLSGtks4L3qX6yYY0zufBnhC8Ur/iy55GhWP/9A/bY2LhC30M9+RYtw=="}
+			}],
+			"certificateAuthorities": [{"uri": "https://fulcio.sigstore.dev", "certChain": {"certificates": []}}]}`),
+			expected: serrors.ErrorInvalidTrustedRoot,
+		},
+		{
+			name: "empty timestamp authority certificate chain",
+			content: []byte(`{"tlogs": [{
+				"baseUrl": "https://rekor.sigstore.dev",
+				"publicKey": {"rawBytes": "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE2G2Y+2tabdTV5BcGiBIx0a9fAFwrkBbmLSGtks4L3qX6yYY0zufBnhC8Ur/iy55GhWP/9A/bY2LhC30M9+RYtw=="}
+			}],
+			"timestampAuthorities": [{"uri": "https://timestamp.example.com", "certChain": {"certificates": []}}]}`),
+			expected: serrors.ErrorInvalidTrustedRoot,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			trustedRoot, err := TrustedRootFromJSON(tt.content)
+			if !errCmp(err, tt.expected) {
+				t.Fatalf(cmp.Diff(err, tt.expected))
+			}
+			if err != nil {
+				return
+			}
+
+			for _, id := range tt.rekorIDs {
+				if _, ok := trustedRoot.RekorPubKeys.Keys[id]; !ok {
+					t.Errorf("missing Rekor key %s", id)
+				}
+			}
+			for _, id := range tt.ctIDs {
+				if _, ok := trustedRoot.CTPubKeys.Keys[id]; !ok {
+					t.Errorf("missing CT log key %s", id)
+				}
+			}
+			if len(trustedRoot.TimestampAuthorities) != tt.tsas {
+				t.Errorf("unexpected number of timestamp authorities: %d", len(trustedRoot.TimestampAuthorities))
+			}
+		})
+	}
+}
+
+func Test_verifyBundleWithTrustedRootFile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	trustedRoot, err := TrustedRootFromFile("./testdata/trusted_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		path     string
+		expected error
+	}{
+		{
+			name: "valid bundle",
+			path: "./testdata/bundle/valid.intoto.sigstore",
+		},
+		{
+			name:     "mismatch rekor entry",
+			path:     "./testdata/bundle/mismatch-tlog.intoto.sigstore",
+			expected: ErrorMismatchSignature,
+		},
+		{
+			name:     "mismatch certificate",
+			path:     "./testdata/bundle/mismatch-cert.intoto.sigstore",
+			expected: ErrorMismatchCertInBundle,
+		},
+		{
+			name:     "invalid Rekor SET",
+			path:     "./testdata/bundle/invalid-set.intoto.sigstore",
+			expected: serrors.ErrorInvalidRekorEntry,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := os.ReadFile(tt.path)
+			if err != nil {
+				panic(fmt.Errorf("os.ReadFile: %w", err))
+			}
+
+			_, err = VerifyProvenanceBundle(ctx, content, trustedRoot, nil)
+
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+		})
+	}
+}
+
+func Test_TrustedRootWithOpts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	content, err := os.ReadFile("./testdata/trusted_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileRoot, err := TrustedRootFromJSON(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("trusted root in options", func(t *testing.T) {
+		t.Parallel()
+
+		// The trusted root is used without fetching the cached one from TUF.
+		root, err := TrustedRootWithOpts(ctx, &options.VerifierOpts{TrustedRoot: content})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !root.FulcioRoot.Equal(fileRoot.FulcioRoot) {
+			t.Errorf("unexpected Fulcio roots")
+		}
+		if diff := cmp.Diff(fileRoot.RekorPubKeys.Keys, root.RekorPubKeys.Keys); diff != "" {
+			t.Errorf("unexpected Rekor keys (-want +got): \n%s", diff)
+		}
+	})
+
+	t.Run("Fulcio roots override the trusted root", func(t *testing.T) {
+		t.Parallel()
+
+		pool := x509.NewCertPool()
+		root, err := TrustedRootWithOpts(ctx, &options.VerifierOpts{
+			TrustedRoot: content,
+			FulcioRoots: pool,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if root.FulcioRoot != pool {
+			t.Errorf("expected the Fulcio roots of the options")
+		}
+		if root.FulcioIntermediates == nil || !root.FulcioIntermediates.Equal(fileRoot.FulcioIntermediates) {
+			t.Errorf("unexpected Fulcio intermediates")
+		}
+	})
+
+	t.Run("invalid trusted root in options", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := TrustedRootWithOpts(ctx, &options.VerifierOpts{TrustedRoot: []byte("{")})
+		if !errCmp(err, serrors.ErrorInvalidTrustedRoot) {
+			t.Errorf(cmp.Diff(err, serrors.ErrorInvalidTrustedRoot))
+		}
+	})
+}
@@ -6,14 +6,15 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
 	"github.com/sigstore/rekor/pkg/client"
 
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/register"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
@@ -40,28 +41,30 @@ func (v *GHAVerifier) IsAuthoritativeFor(builderID string) bool {
 	return strings.HasPrefix(builderID, httpsGithubCom)
 }
 
-func verifyEnvAndCert(env *dsse.Envelope,
+func verifyEnvAndCert(ctx context.Context, env *dsse.Envelope,
 	cert *x509.Certificate,
+	tlogEntry *utils.TransparencyLogEntry,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
 	defaultBuilders map[string]bool,
-) ([]byte, *utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
 	/* Verify properties of the signing identity. */
 	// Get the workflow info given the certificate information.
 	workflowInfo, err := GetWorkflowInfoFromCertificate(cert)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify the builder identity.
-	builderID, byob, err := VerifyBuilderIdentity(workflowInfo, builderOpts, defaultBuilders)
+	builderID, byob, err := VerifyBuilderIdentity(workflowInfo, builderOpts, defaultBuilders, verifierOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify the source repository from the certificate.
 	if err := VerifyCertficateSourceRepository(workflowInfo, provenanceOpts.ExpectedSourceURI); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify properties of the SLSA provenance.
@@ -74,37 +77,55 @@ func verifyEnvAndCert(env *dsse.Envelope,
 		if builderOpts.ExpectedID == nil || *builderOpts.ExpectedID == "" {
 			// NOTE: we will need to update the logic here once our default trusted builders
 			// are migrated to using BYOB.
-			return nil, nil, fmt.Errorf("%w: empty ID", serrors.ErrorInvalidBuilderID)
+			return nil, fmt.Errorf("%w: empty ID", serrors.ErrorInvalidBuilderID)
 		}
 		provenanceOpts.ExpectedBuilderID = *builderOpts.ExpectedID
 	}
 	if err := VerifyProvenance(env, provenanceOpts, byob); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	fmt.Fprintf(os.Stderr, "Verified build using builder https://github.com%s at commit %s\n",
-		workflowInfo.SubjectWorkflowRef,
-		workflowInfo.SourceSha1)
+	events.Emit(ctx, events.BuildVerified{
+		BuilderID:    httpsGithubCom + workflowInfo.SubjectWorkflowRef,
+		SourceCommit: workflowInfo.SourceSha1,
+	})
 	// Return verified provenance.
 	r, err := base64.StdEncoding.DecodeString(env.Payload)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return r, builderID, nil
+	result := &utils.VerificationResult{
+		Statement:            r,
+		BuilderID:            builderID,
+		SourceURI:            httpsGithubCom + workflowInfo.SourceRepository,
+		SourceCommit:         workflowInfo.SourceSha1,
+		WorkflowIdentity:     workflowInfo.result(),
+		TransparencyLogEntry: tlogEntry,
+		// Trusted reusable workflows meet the requirements of SLSA Build L3.
+		BuildLevel: 3,
+	}
+	result.AddChecks(utils.CheckSignature)
+	if tlogEntry != nil {
+		result.AddChecks(utils.CheckTransparencyLog)
+	}
+	result.AddChecks(utils.CheckBuilderID)
+	result.AddChecks(provenanceChecks(provenanceOpts)...)
+	return result, nil
 }
 
-func verifyNpmEnvAndCert(env *dsse.Envelope,
+func verifyNpmEnvAndCert(ctx context.Context, env *dsse.Envelope,
 	cert *x509.Certificate,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
 	defaultBuilders map[string]bool,
-) (*utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.TrustedBuilderID, *WorkflowIdentity, error) {
 	/* Verify properties of the signing identity. */
 	// Get the workflow info given the certificate information.
 	workflowInfo, err := GetWorkflowInfoFromCertificate(cert)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Verify the workflow identity.
@@ -115,21 +136,21 @@ func verifyNpmEnvAndCert(env *dsse.Envelope,
 	delegatorBuilderOpts := options.BuilderOpts{
 		ExpectedID: &expectedDelegatorWorkflow,
 	}
-	trustedBuilderID, byob, err := VerifyBuilderIdentity(workflowInfo, &delegatorBuilderOpts, defaultBuilders)
+	trustedBuilderID, byob, err := VerifyBuilderIdentity(workflowInfo, &delegatorBuilderOpts, defaultBuilders, verifierOpts)
 	// We accept a non-trusted builder for the default npm builder
 	// that uses npm CLI.
 	if err != nil && !errors.Is(err, serrors.ErrorUntrustedReusableWorkflow) {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Verify the source repository from the certificate.
 	if err := VerifyCertficateSourceRepository(workflowInfo, provenanceOpts.ExpectedSourceURI); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Users must always provide the builder ID.
 	if builderOpts == nil || builderOpts.ExpectedID == nil {
-		return nil, fmt.Errorf("builder ID is empty")
+		return nil, nil, fmt.Errorf("builder ID is empty")
 	}
 
 	// WARNING: builderID may be empty if it's not a trusted reusable builder workflow.
@@ -141,12 +162,12 @@ func verifyNpmEnvAndCert(env *dsse.Envelope,
 		// The delegator workflow will set the builder ID to the caller's path,
 		// which is what users match against.
 		if !byob {
-			return nil, fmt.Errorf("%w: byob is false", serrors.ErrorInternal)
+			return nil, nil, fmt.Errorf("%w: byob is false", serrors.ErrorInternal)
 		}
 		provenanceOpts.ExpectedBuilderID = *builderOpts.ExpectedID
 
 		if workflowInfo.SubjectHosted != nil && *workflowInfo.SubjectHosted != HostedGitHub {
-			return nil, fmt.Errorf("%w: self hosted re-usable workflow", serrors.ErrorMismatchBuilderID)
+			return nil, nil, fmt.Errorf("%w: self hosted re-usable workflow", serrors.ErrorMismatchBuilderID)
 		}
 		isTrustedBuilder = true
 	} else {
@@ -165,25 +186,25 @@ func verifyNpmEnvAndCert(env *dsse.Envelope,
 		// Verify that the value provided is consistent with certificate information.
 
 		if workflowInfo.SubjectHosted == nil {
-			return nil, fmt.Errorf("%w: hosted status unknonwn", serrors.ErrorNotSupported)
+			return nil, nil, fmt.Errorf("%w: hosted status unknonwn", serrors.ErrorNotSupported)
 		}
 		switch *builderOpts.ExpectedID {
 		case builderLegacyGitHubRunnerID, builderGitHubHostedRunnerID:
 			if *workflowInfo.SubjectHosted != HostedGitHub {
-				return nil, fmt.Errorf("%w: re-usable workflow is self-hosted", serrors.ErrorMismatchBuilderID)
+				return nil, nil, fmt.Errorf("%w: re-usable workflow is self-hosted", serrors.ErrorMismatchBuilderID)
 			}
 		case builderSelfHostedRunnerID:
 			if *workflowInfo.SubjectHosted != HostedSelf {
-				return nil, fmt.Errorf("%w: re-usable workflow is GitHub-hosted", serrors.ErrorMismatchBuilderID)
+				return nil, nil, fmt.Errorf("%w: re-usable workflow is GitHub-hosted", serrors.ErrorMismatchBuilderID)
 			}
 		default:
-			return nil, fmt.Errorf("%w: builder %v. Expected one of %v, %v", serrors.ErrorNotSupported, *builderOpts.ExpectedID,
+			return nil, nil, fmt.Errorf("%w: builder %v. Expected one of %v, %v", serrors.ErrorNotSupported, *builderOpts.ExpectedID,
 				builderSelfHostedRunnerID, builderGitHubHostedRunnerID)
 		}
 
 		trustedBuilderID, err = utils.TrustedBuilderIDNew(*builderOpts.ExpectedID, false)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// On GitHub we only support the default GitHub runner builder.
@@ -193,50 +214,332 @@ func verifyNpmEnvAndCert(env *dsse.Envelope,
 	// Verify properties of the SLSA provenance.
 	// Unpack and verify info in the provenance, including the Subject Digest.
 	if err := VerifyNpmPackageProvenance(env, workflowInfo, provenanceOpts, isTrustedBuilder); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	fmt.Fprintf(os.Stderr, "Verified build using builder %s at commit %s\n",
-		trustedBuilderID.String(),
-		workflowInfo.SourceSha1)
+	events.Emit(ctx, events.BuildVerified{
+		BuilderID:    trustedBuilderID.String(),
+		SourceCommit: workflowInfo.SourceSha1,
+	})
+
+	return trustedBuilderID, workflowInfo, nil
+}
+
+// npmBuildLevel returns the SLSA build level achieved by an npm builder.
+// Provenance generated by the npm CLI on a GitHub-hosted runner is
+// SLSA Build L2, and on a self-hosted runner SLSA Build L1.
+func npmBuildLevel(builderID *utils.TrustedBuilderID) int {
+	switch {
+	case !isRunnerBuilder(builderID):
+		return 3
+	case builderID.Name() == builderSelfHostedRunnerID:
+		return 1
+	default:
+		return 2
+	}
+}
 
-	return trustedBuilderID, nil
+// isRunnerBuilder returns true if the package was built by the npm CLI
+// on a GitHub runner instead of a trusted builder.
+func isRunnerBuilder(builderID *utils.TrustedBuilderID) bool {
+	switch builderID.Name() {
+	case builderLegacyGitHubRunnerID, builderGitHubHostedRunnerID, builderSelfHostedRunnerID:
+		return true
+	default:
+		return false
+	}
 }
 
 // VerifyArtifact verifies provenance for an artifact.
+// The provenance may be in the JSON Lines format, with one attestation per
+// line. In that case, each attestation is verified in turn and verification
+// succeeds with the first one that verifies.
 func (v *GHAVerifier) VerifyArtifact(ctx context.Context,
 	provenance []byte, artifactHash string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	return VerifyProvenanceLines(ctx, provenance,
+		func(attestation []byte) (*utils.VerificationResult, error) {
+			return verifyArtifactAttestation(ctx, attestation, artifactHash,
+				provenanceOpts, builderOpts, verifierOpts)
+		})
+}
+
+// VerifyProvenanceLines verifies the attestations of provenance in the
+// JSON Lines format in turn with verify, and returns the result of the
+// first one that verifies. Provenance that is a single JSON document is
+// verified as is.
+func VerifyProvenanceLines(ctx context.Context, provenance []byte,
+	verify func(attestation []byte) (*utils.VerificationResult, error),
+) (*utils.VerificationResult, error) {
+	lines, err := provenanceLines(provenance)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return verify(provenance)
+	}
+
+	var errs []error
+	for _, line := range lines {
+		if line.err != nil {
+			errs = append(errs, line.err)
+			continue
+		}
+		result, err := verify(line.content)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line.number, err))
+			continue
+		}
+		events.Emit(ctx, events.AttestationVerified{Line: line.number})
+		result.ProvenanceLine = line.number
+		return result, nil
+	}
+
+	// Return the first error.
+	var s string
+	if len(errs) > 1 {
+		s = fmt.Sprintf(": %v", errs[1:])
+	}
+	return nil, fmt.Errorf("%w%s", errs[0], s)
+}
+
+// verifyArtifactAttestation verifies a single attestation for an artifact.
+func verifyArtifactAttestation(ctx context.Context,
+	provenance []byte, artifactHash string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	signedAtt, err := VerifyArtifactSignature(ctx, provenance, artifactHash, verifierOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifyArtifactEnvAndCert(ctx, signedAtt, provenanceOpts, builderOpts, verifierOpts)
+}
+
+// VerifyArtifactSignature verifies the signature on a single attestation for an
+// artifact. The artifact hash is only used to search Rekor when the attestation
+// does not contain the signing certificate.
+func VerifyArtifactSignature(ctx context.Context,
+	provenance []byte, artifactHash string,
+	verifierOpts *options.VerifierOpts,
+) (*SignedAttestation, error) {
+	// Only the Rekor search of provenance without a certificate
+	// depends on the artifact.
+	var searchHash string
+	if !IsSigstoreBundle(provenance) && !hasCertInEnvelope(provenance) {
+		searchHash = artifactHash
+	}
+	return verifySignatureOnce(ctx, provenance, searchHash, verifierOpts,
+		func() (*SignedAttestation, error) {
+			return verifyArtifactSignature(ctx, provenance, artifactHash, verifierOpts)
+		})
+}
+
+// VerifyArtifactsSignature verifies the signature of provenance covering
+// several artifacts. The Rekor search for provenance without a certificate
+// is by artifact digest, so the artifacts are searched in turn until the
+// entry of the provenance is found.
+func VerifyArtifactsSignature(ctx context.Context,
+	provenance []byte, artifactHashes []string,
+	verifierOpts *options.VerifierOpts,
+) (*SignedAttestation, error) {
+	if len(artifactHashes) == 0 {
+		return nil, fmt.Errorf("%w: no artifact", serrors.ErrorInvalidHash)
+	}
+	if IsSigstoreBundle(provenance) || hasCertInEnvelope(provenance) {
+		return VerifyArtifactSignature(ctx, provenance, artifactHashes[0], verifierOpts)
+	}
+
+	var firstErr error
+	for _, artifactHash := range artifactHashes {
+		att, err := VerifyArtifactSignature(ctx, provenance, artifactHash, verifierOpts)
+		if err == nil {
+			return att, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		// Only the Rekor search depends on the artifact.
+		if !errors.Is(err, serrors.ErrorRekorSearch) &&
+			!errors.Is(err, serrors.ErrorNoValidRekorEntries) {
+			break
+		}
+	}
+	return nil, firstErr
+}
+
+func verifyArtifactSignature(ctx context.Context,
+	provenance []byte, artifactHash string,
+	verifierOpts *options.VerifierOpts,
+) (*SignedAttestation, error) {
 	isSigstoreBundle := IsSigstoreBundle(provenance)
 
+	// Only Sigstore bundles may carry entries from several logs.
+	if !isSigstoreBundle && verifierOpts != nil && verifierOpts.MinTlogEntries > 1 {
+		return nil, fmt.Errorf("%w: requiring several tlog entries for provenance that is not a Sigstore bundle",
+			serrors.ErrorNotSupported)
+	}
+
 	// This includes a default retry count of 3.
-	rClient, err := client.GetRekorClient(defaultRekorAddr)
+	rClient, err := client.GetRekorClient(rekorAddr(verifierOpts))
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	trustedRoot, err := TrustedRootSingleton(ctx)
+	trustedRoot, err := TrustedRootWithOpts(ctx, verifierOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	var signedAtt *SignedAttestation
 	/* Verify signature on the intoto attestation. */
+	var signedAtt *SignedAttestation
 	if isSigstoreBundle {
-		signedAtt, err = VerifyProvenanceBundle(ctx, provenance, trustedRoot)
+		signedAtt, err = VerifyProvenanceBundle(ctx, provenance, trustedRoot, verifierOpts)
 	} else {
 		signedAtt, err = VerifyProvenanceSignature(ctx, trustedRoot, rClient,
-			provenance, artifactHash)
+			provenance, artifactHash, verifierOpts)
 	}
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	events.Emit(ctx, events.SignatureVerified{Signer: certSigner(signedAtt.SigningCert)})
+	return signedAtt, nil
+}
+
+// certSigner returns the identity of the signing certificate.
+func certSigner(cert *x509.Certificate) string {
+	if cert == nil || len(cert.URIs) == 0 {
+		return ""
 	}
+	return cert.URIs[0].String()
+}
 
-	return verifyEnvAndCert(signedAtt.Envelope, signedAtt.SigningCert,
+func verifyArtifactEnvAndCert(ctx context.Context, signedAtt *SignedAttestation,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	return verifyEnvAndCert(ctx, signedAtt.Envelope, signedAtt.SigningCert,
+		TlogEntryResult(signedAtt.RekorEntry, verifierOpts),
 		provenanceOpts, builderOpts,
-		utils.MergeMaps(defaultArtifactTrustedReusableWorkflows, defaultBYOBReusableWorkflows))
+		utils.MergeMaps(defaultArtifactTrustedReusableWorkflows, defaultBYOBReusableWorkflows),
+		verifierOpts)
+}
+
+// VerifyArtifacts verifies provenance for several artifacts covered by the
+// same provenance. The signature of each attestation in the provenance is
+// verified once, then the properties of the provenance, including the subject
+// digest, are verified for each artifact. An artifact passes verification if
+// it passes with any of the attestations.
+func (v *GHAVerifier) VerifyArtifacts(ctx context.Context,
+	provenance []byte, artifactHashes []string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) ([]utils.ArtifactResult, error) {
+	return VerifyArtifactsProvenanceLines(ctx, provenance, artifactHashes, verifierOpts,
+		func(att *SignedAttestation, artifactHash string) (*utils.VerificationResult, error) {
+			// The options are updated during verification.
+			opts := *provenanceOpts
+			opts.ExpectedDigest = artifactHash
+			return verifyArtifactEnvAndCert(ctx, att, &opts, builderOpts, verifierOpts)
+		})
+}
+
+// VerifyArtifactsProvenanceLines verifies the signature of each attestation
+// of provenance in the JSON Lines format once, then verifies each artifact
+// with verify against the attestations in turn. Provenance that is a single
+// JSON document is verified as is.
+func VerifyArtifactsProvenanceLines(ctx context.Context,
+	provenance []byte, artifactHashes []string,
+	verifierOpts *options.VerifierOpts,
+	verify func(att *SignedAttestation, artifactHash string) (*utils.VerificationResult, error),
+) ([]utils.ArtifactResult, error) {
+	if len(artifactHashes) == 0 {
+		return nil, nil
+	}
+
+	lines, err := provenanceLines(provenance)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		lines = []provenanceLine{{content: provenance}}
+	}
+
+	// Verify the signatures once.
+	type signedLine struct {
+		number int
+		att    *SignedAttestation
+	}
+	var signed []signedLine
+	var errs []error
+	for _, line := range lines {
+		if line.err != nil {
+			errs = append(errs, line.err)
+			continue
+		}
+		att, err := VerifyArtifactsSignature(ctx, line.content, artifactHashes, verifierOpts)
+		if err != nil {
+			if line.number > 0 {
+				err = fmt.Errorf("line %d: %w", line.number, err)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		signed = append(signed, signedLine{number: line.number, att: att})
+	}
+	if len(signed) == 0 {
+		// Return the first error.
+		var s string
+		if len(errs) > 1 {
+			s = fmt.Sprintf(": %v", errs[1:])
+		}
+		return nil, fmt.Errorf("%w%s", errs[0], s)
+	}
+
+	results := make([]utils.ArtifactResult, len(artifactHashes))
+	for i, artifactHash := range artifactHashes {
+		results[i].ArtifactHash = artifactHash
+		for _, sl := range signed {
+			result, err := verify(sl.att, artifactHash)
+			if err != nil {
+				if sl.number > 0 {
+					err = fmt.Errorf("line %d: %w", sl.number, err)
+				}
+				// Keep the first error.
+				if results[i].Err == nil {
+					results[i].Err = err
+				}
+				continue
+			}
+			result.ProvenanceLine = sl.number
+			results[i].Result = result
+			results[i].Err = nil
+			break
+		}
+	}
+	return results, nil
+}
+
+// CheckImageVerifierOpts returns an error for the transparency log options
+// that cannot be enforced when the image attestations are verified by cosign.
+func CheckImageVerifierOpts(verifierOpts *options.VerifierOpts) error {
+	if verifierOpts == nil {
+		return nil
+	}
+	if verifierOpts.RequireInclusionProof {
+		return fmt.Errorf("%w: requiring an inclusion proof for images", serrors.ErrorNotSupported)
+	}
+	if verifierOpts.MinTlogEntries > 1 {
+		return fmt.Errorf("%w: requiring several tlog entries for images", serrors.ErrorNotSupported)
+	}
+	return nil
 }
 
 // VerifyImage verifies provenance for an OCI image.
@@ -244,50 +547,65 @@ func (v *GHAVerifier) VerifyImage(ctx context.Context,
 	provenance []byte, artifactImage string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	if err := CheckImageVerifierOpts(verifierOpts); err != nil {
+		return nil, err
+	}
+
 	/* Retrieve any valid signed attestations that chain up to Fulcio root CA. */
-	trustedRoot, err := TrustedRootSingleton(ctx)
+	trustedRoot, err := TrustedRootWithOpts(ctx, verifierOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	opts := &cosign.CheckOpts{
 		RootCerts:         trustedRoot.FulcioRoot,
 		IntermediateCerts: trustedRoot.FulcioIntermediates,
 		RekorPubKeys:      trustedRoot.RekorPubKeys,
 		CTLogPubKeys:      trustedRoot.CTPubKeys,
+		Identities:        CertIdentities(verifierOpts),
 	}
 
 	atts, _, err := container.RunCosignImageVerification(ctx,
 		artifactImage, opts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	/* Now verify properties of the attestations */
 	var errs []error
-	var builderID *utils.TrustedBuilderID
-	var verifiedProvenance []byte
 	for _, att := range atts {
 		pyld, err := att.Payload()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "unexpected error getting payload from OCI registry %s", err)
+			events.Emit(ctx, events.Warning{Message: fmt.Sprintf("unexpected error getting payload from OCI registry %s", err)})
 			continue
 		}
 		env, err := EnvelopeFromBytes(pyld)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "unexpected error parsing envelope from OCI registry %s", err)
+			events.Emit(ctx, events.Warning{Message: fmt.Sprintf("unexpected error parsing envelope from OCI registry %s", err)})
 			continue
 		}
 		cert, err := att.Cert()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "unexpected error getting certificate from OCI registry %s", err)
+			events.Emit(ctx, events.Warning{Message: fmt.Sprintf("unexpected error getting certificate from OCI registry %s", err)})
 			continue
 		}
-		verifiedProvenance, builderID, err = verifyEnvAndCert(env,
-			cert, provenanceOpts, builderOpts,
-			defaultContainerTrustedReusableWorkflows)
+		// The Rekor bundle has been verified by cosign.
+		var tlogEntry *utils.TransparencyLogEntry
+		if bundle, err := att.Bundle(); err == nil && bundle != nil {
+			tlogEntry = &utils.TransparencyLogEntry{
+				URL: fmt.Sprintf("%v/%v?logIndex=%d", rekorAddr(verifierOpts),
+					"api/v1/log/entries", bundle.Payload.LogIndex),
+				LogID:          bundle.Payload.LogID,
+				LogIndex:       bundle.Payload.LogIndex,
+				IntegratedTime: time.Unix(bundle.Payload.IntegratedTime, 0),
+			}
+		}
+		result, err := verifyEnvAndCert(ctx, env,
+			cert, tlogEntry, provenanceOpts, builderOpts,
+			defaultContainerTrustedReusableWorkflows, verifierOpts)
 		if err == nil {
-			return verifiedProvenance, builderID, nil
+			return result, nil
 		}
 		errs = append(errs, err)
 	}
@@ -298,9 +616,9 @@ func (v *GHAVerifier) VerifyImage(ctx context.Context,
 		if len(errs) > 1 {
 			s = fmt.Sprintf(": %v", errs[1:])
 		}
-		return nil, nil, fmt.Errorf("%w%s", errs[0], s)
+		return nil, fmt.Errorf("%w%s", errs[0], s)
 	}
-	return nil, nil, fmt.Errorf("%w", serrors.ErrorNoValidSignature)
+	return nil, fmt.Errorf("%w", serrors.ErrorNoValidSignature)
 }
 
 // VerifyNpmPackage verifies an npm package tarball.
@@ -308,56 +626,81 @@ func (v *GHAVerifier) VerifyNpmPackage(ctx context.Context,
 	attestations []byte, tarballHash string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
-	trustedRoot, err := TrustedRootSingleton(ctx)
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	trustedRoot, err := TrustedRootWithOpts(ctx, verifierOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	npm, err := NpmNew(ctx, trustedRoot, attestations)
+	npm, err := NpmNew(ctx, trustedRoot, attestations, verifierOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify provenance signature.
 	if err := npm.verifyProvenanceAttestationSignature(); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify publish attesttation signature.
 	if err := npm.verifyPublishAttesttationSignature(); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Verify attestation headers.
 	if err := npm.verifyIntotoHeaders(); err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+
+	checks := []string{
+		utils.CheckSignature, utils.CheckTransparencyLog,
+		utils.CheckPublishSignature, utils.CheckIntotoHeaders,
 	}
 
 	// Verify package names match.
 	if provenanceOpts != nil {
 		if err := npm.verifyPackageName(provenanceOpts.ExpectedPackageName); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		if err := npm.verifyPackageVersion(provenanceOpts.ExpectedPackageVersion); err != nil {
-			return nil, nil, err
+			return nil, err
+		}
+
+		if provenanceOpts.ExpectedPackageName != nil {
+			checks = append(checks, utils.CheckPackageName)
+		}
+		if provenanceOpts.ExpectedPackageVersion != nil {
+			checks = append(checks, utils.CheckPackageVersion)
 		}
 	}
 
 	// Verify certificate information.
-	builder, err := verifyNpmEnvAndCert(npm.ProvenanceEnvelope(),
+	builder, workflowInfo, err := verifyNpmEnvAndCert(ctx, npm.ProvenanceEnvelope(),
 		npm.ProvenanceLeafCertificate(),
 		provenanceOpts, builderOpts,
-		defaultBYOBReusableWorkflows)
+		defaultBYOBReusableWorkflows, verifierOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	prov, err := npm.verifiedProvenanceBytes()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return prov, builder, nil
+	result := &utils.VerificationResult{
+		Statement:            prov,
+		BuilderID:            builder,
+		SourceURI:            httpsGithubCom + workflowInfo.SourceRepository,
+		SourceCommit:         workflowInfo.SourceSha1,
+		WorkflowIdentity:     workflowInfo.result(),
+		TransparencyLogEntry: TlogEntryResult(npm.verifiedProvenanceAtt.RekorEntry, verifierOpts),
+		Checks:               checks,
+		BuildLevel:           npmBuildLevel(builder),
+	}
+	result.AddChecks(utils.CheckBuilderID)
+	result.AddChecks(provenanceChecks(provenanceOpts)...)
+	return result, nil
 }
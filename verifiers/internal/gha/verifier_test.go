@@ -0,0 +1,248 @@
+package gha
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+func Test_VerifyArtifact_provenanceLines(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	trustedRoot, err := os.ReadFile("./testdata/trusted_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile("./testdata/bundle/container-based.intoto.sigstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var valid bytes.Buffer
+	if err := json.Compact(&valid, content); err != nil {
+		t.Fatal(err)
+	}
+
+	artifactHash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_container-based_slsa3.yml"
+	tests := []struct {
+		name       string
+		provenance string
+		line       int
+		expected   error
+	}{
+		{
+			name:       "single document",
+			provenance: string(content),
+		},
+		{
+			name:       "invalid line before valid line",
+			provenance: "{\"payloadType\": \n" + valid.String() + "\n",
+			line:       2,
+		},
+		{
+			name:       "valid line before invalid line",
+			provenance: valid.String() + "\nnot json\n",
+			line:       1,
+		},
+		{
+			name:       "invalid lines",
+			provenance: "not json\n{\"payloadType\": \n",
+			expected:   serrors.ErrorInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			provenanceOpts := &options.ProvenanceOpts{
+				ExpectedSourceURI: "github.com/slsa-framework/example-package",
+				ExpectedDigest:    artifactHash,
+			}
+			builderOpts := &options.BuilderOpts{ExpectedID: &builderID}
+			verifierOpts := &options.VerifierOpts{TrustedRoot: trustedRoot}
+
+			v := &GHAVerifier{}
+			result, err := v.VerifyArtifact(ctx, []byte(tt.provenance), artifactHash,
+				provenanceOpts, builderOpts, verifierOpts)
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+			if err == nil && result.ProvenanceLine != tt.line {
+				t.Errorf("unexpected line: %d, want %d", result.ProvenanceLine, tt.line)
+			}
+
+			results, err := v.VerifyArtifacts(ctx, []byte(tt.provenance), []string{artifactHash},
+				provenanceOpts, builderOpts, verifierOpts)
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+			if err != nil {
+				return
+			}
+			if len(results) != 1 {
+				t.Fatalf("unexpected results: %v", results)
+			}
+			if results[0].Err != nil {
+				t.Fatalf("unexpected error: %v", results[0].Err)
+			}
+			if results[0].Result.ProvenanceLine != tt.line {
+				t.Errorf("unexpected line: %d, want %d", results[0].Result.ProvenanceLine, tt.line)
+			}
+		})
+	}
+}
+
+func Test_VerifyArtifactsSignature(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	trustedRoot, err := os.ReadFile("./testdata/trusted_root.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := os.ReadFile("./testdata/bundle/container-based.intoto.sigstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An envelope without a certificate is searched in Rekor by artifact digest.
+	envelope := []byte(`{"payloadType": "application/vnd.in-toto+json", "payload": "e30=", "signatures": [{"sig": "c2ln"}]}`)
+
+	tests := []struct {
+		name           string
+		provenance     []byte
+		artifactHashes []string
+		searches       []string
+		minTlogEntries int
+		expected       error
+	}{
+		{
+			name:           "bundle",
+			provenance:     bundle,
+			artifactHashes: []string{"abcd", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		},
+		{
+			name:           "search each artifact",
+			provenance:     envelope,
+			artifactHashes: []string{"abcd", "ef01"},
+			searches:       []string{"sha256:abcd", "sha256:ef01"},
+			expected:       serrors.ErrorRekorSearch,
+		},
+		{
+			name:           "several tlog entries in bundle",
+			provenance:     bundle,
+			artifactHashes: []string{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			minTlogEntries: 2,
+			expected:       serrors.ErrorInvalidRekorEntry,
+		},
+		{
+			name:           "several tlog entries without bundle",
+			provenance:     envelope,
+			artifactHashes: []string{"abcd", "ef01"},
+			minTlogEntries: 2,
+			expected:       serrors.ErrorNotSupported,
+		},
+		{
+			name:     "no artifact",
+			expected: serrors.ErrorInvalidHash,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The Rekor index has no entry.
+			var mu sync.Mutex
+			var searches []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/index/retrieve") {
+					http.NotFound(w, r)
+					return
+				}
+				var query struct {
+					Hash string `json:"hash"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				mu.Lock()
+				searches = append(searches, query.Hash)
+				mu.Unlock()
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, "[]")
+			}))
+			defer server.Close()
+
+			_, err := VerifyArtifactsSignature(ctx, tt.provenance, tt.artifactHashes,
+				&options.VerifierOpts{
+					TrustedRoot:    trustedRoot,
+					RekorURL:       server.URL,
+					MinTlogEntries: tt.minTlogEntries,
+				})
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+			if diff := cmp.Diff(tt.searches, searches); diff != "" {
+				t.Errorf("unexpected searches (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_CheckImageVerifierOpts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		verifierOpts *options.VerifierOpts
+		expected     error
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name:         "default options",
+			verifierOpts: &options.VerifierOpts{},
+		},
+		{
+			name:         "require inclusion proof",
+			verifierOpts: &options.VerifierOpts{RequireInclusionProof: true},
+			expected:     serrors.ErrorNotSupported,
+		},
+		{
+			name:         "single tlog entry",
+			verifierOpts: &options.VerifierOpts{MinTlogEntries: 1},
+		},
+		{
+			name:         "several tlog entries",
+			verifierOpts: &options.VerifierOpts{MinTlogEntries: 2},
+			expected:     serrors.ErrorNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckImageVerifierOpts(tt.verifierOpts)
+			if !errCmp(err, tt.expected) {
+				t.Errorf(cmp.Diff(err, tt.expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package gitlab
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"strings"
+
+	fulcio "github.com/sigstore/fulcio/pkg/certificate"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+var (
+	certOidcIssuer = "https://gitlab.com"
+	httpsGitlabCom = certOidcIssuer + "/"
+	// This is used in cosign's CheckOpts for validating the certificate.
+	// We do specific pipeline verification after this.
+	certSubjectRegexp = "^" + httpsGitlabCom
+)
+
+// Runner environments of GitLab CI jobs.
+const (
+	runnerGitLabHosted = "gitlab-hosted"
+	runnerSelfHosted   = "self-hosted"
+)
+
+// Builders of GitLab CI jobs. The builder is the GitLab Runner in the
+// runner environment recorded in the certificate.
+var (
+	builderGitLabRunnerID       = httpsGitlabCom + "gitlab-org/gitlab-runner"
+	builderGitLabHostedRunnerID = builderGitLabRunnerID + "/" + runnerGitLabHosted
+	builderSelfHostedRunnerID   = builderGitLabRunnerID + "/" + runnerSelfHosted
+)
+
+// PipelineIdentity is the identity of the GitLab CI job that signed the
+// provenance, as found in the Fulcio signing certificate.
+// See https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md.
+type PipelineIdentity struct {
+	// The OIDC issuer, i.e. the URL of the GitLab instance.
+	Issuer string
+	// The subject alternative name of the certificate. For GitLab, this is
+	// the URI of the pipeline configuration at the ref it ran on.
+	SubjectURI string
+
+	// The project URI, e.g. https://gitlab.com/group/project.
+	SourceRepositoryURI string
+	// The commit SHA the pipeline ran on.
+	SourceSha1 string
+	// The ref the pipeline ran on, e.g. refs/heads/main.
+	SourceRef string
+	// The ID of the project.
+	SourceID string
+	// The URI of the project namespace.
+	SourceOwnerURI string
+	// The ID of the project namespace.
+	SourceOwnerID string
+
+	// The URI of the pipeline configuration that signed the provenance.
+	BuildSignerURI string
+	// The commit SHA of the signing pipeline configuration.
+	BuildSignerSha1 string
+	// The URI of the top-level pipeline configuration,
+	// e.g. https://gitlab.com/group/project//.gitlab-ci.yml@refs/heads/main.
+	BuildConfigURI string
+	// The commit SHA of the top-level pipeline configuration.
+	BuildConfigSha1 string
+	// The pipeline source, e.g. push.
+	BuildTrigger string
+	// The URI of the job, e.g. https://gitlab.com/group/project/-/jobs/1.
+	RunInvocationURI string
+	// The runner environment, gitlab-hosted or self-hosted.
+	RunnerEnvironment string
+}
+
+// result returns the identity in the form exposed by utils.VerificationResult.
+func (id *PipelineIdentity) result() *utils.WorkflowIdentity {
+	return &utils.WorkflowIdentity{
+		Issuer:             id.Issuer,
+		SourceRepository:   strings.TrimPrefix(id.SourceRepositoryURI, id.Issuer+"/"),
+		SourceSha1:         id.SourceSha1,
+		SourceRef:          id.SourceRef,
+		SubjectWorkflowRef: id.BuildSignerURI,
+		BuildTrigger:       id.BuildTrigger,
+		BuildConfigPath:    id.BuildConfigURI,
+		RunID:              id.RunInvocationURI,
+		Hosted:             id.RunnerEnvironment,
+	}
+}
+
+// GetPipelineInfoFromCertificate gets the pipeline identity from the
+// Fulcio certificate of a GitLab CI job.
+func GetPipelineInfoFromCertificate(cert *x509.Certificate) (*PipelineIdentity, error) {
+	if len(cert.URIs) == 0 {
+		return nil, fmt.Errorf("%w: missing URI information from certificate", serrors.ErrorInvalidFormat)
+	}
+
+	id := &PipelineIdentity{
+		SubjectURI: cert.URIs[0].String(),
+	}
+	// GitLab certificates only contain the extensions of the current format,
+	// which are all DER-encoded.
+	claims := []struct {
+		oid   asn1.ObjectIdentifier
+		value *string
+	}{
+		{fulcio.OIDIssuerV2, &id.Issuer},
+		{fulcio.OIDBuildSignerURI, &id.BuildSignerURI},
+		{fulcio.OIDBuildSignerDigest, &id.BuildSignerSha1},
+		{fulcio.OIDRunnerEnvironment, &id.RunnerEnvironment},
+		{fulcio.OIDSourceRepositoryURI, &id.SourceRepositoryURI},
+		{fulcio.OIDSourceRepositoryDigest, &id.SourceSha1},
+		{fulcio.OIDSourceRepositoryRef, &id.SourceRef},
+		{fulcio.OIDSourceRepositoryIdentifier, &id.SourceID},
+		{fulcio.OIDSourceRepositoryOwnerURI, &id.SourceOwnerURI},
+		{fulcio.OIDSourceRepositoryOwnerIdentifier, &id.SourceOwnerID},
+		{fulcio.OIDBuildConfigURI, &id.BuildConfigURI},
+		{fulcio.OIDBuildConfigDigest, &id.BuildConfigSha1},
+		{fulcio.OIDBuildTrigger, &id.BuildTrigger},
+		{fulcio.OIDRunInvocationURI, &id.RunInvocationURI},
+	}
+	for _, c := range claims {
+		v, err := getExtension(cert, c.oid)
+		if err != nil {
+			return nil, err
+		}
+		*c.value = v
+	}
+
+	// The claims needed to verify the pipeline must be present.
+	if id.Issuer == "" || id.SourceRepositoryURI == "" || id.SourceSha1 == "" ||
+		id.SourceRef == "" || id.BuildSignerURI == "" || id.BuildConfigURI == "" ||
+		id.RunInvocationURI == "" {
+		return nil, fmt.Errorf("%w: missing GitLab claims in certificate", serrors.ErrorInvalidFormat)
+	}
+
+	return id, nil
+}
+
+func getExtension(cert *x509.Certificate, oid asn1.ObjectIdentifier) (string, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oid) {
+			continue
+		}
+
+		var decoded string
+		rest, err := asn1.Unmarshal(ext.Value, &decoded)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", serrors.ErrorInvalidFormat, err)
+		}
+		if len(rest) != 0 {
+			return "", fmt.Errorf("%w: decoding has rest for oid %v", serrors.ErrorInvalidFormat, oid)
+		}
+		return decoded, nil
+	}
+	return "", nil
+}
+
+// certOIDCIssuers returns the OIDC issuers accepted for signing certificates.
+func certOIDCIssuers(verifierOpts *options.VerifierOpts) []string {
+	if verifierOpts == nil || len(verifierOpts.CertOIDCIssuers) == 0 {
+		return []string{certOidcIssuer}
+	}
+	return verifierOpts.CertOIDCIssuers
+}
+
+// gitlabVerifierOpts returns the verifier options with the GitLab
+// defaults for the accepted certificate identities.
+func gitlabVerifierOpts(verifierOpts *options.VerifierOpts) *options.VerifierOpts {
+	var opts options.VerifierOpts
+	if verifierOpts != nil {
+		opts = *verifierOpts
+	}
+	opts.CertOIDCIssuers = certOIDCIssuers(verifierOpts)
+	if len(opts.CertSubjectRegexps) == 0 {
+		opts.CertSubjectRegexps = []string{certSubjectRegexp}
+	}
+	return &opts
+}
+
+// VerifyPipelineIdentity verifies the pipeline identity in the certificate.
+// The issuer must be trusted, and the provenance must have been signed
+// by a job of the project's own pipeline, using the pipeline
+// configuration at the commit and ref the pipeline ran on.
+func VerifyPipelineIdentity(id *PipelineIdentity, verifierOpts *options.VerifierOpts) error {
+	// Issuer verification.
+	// NOTE: this is necessary before we do any further verification.
+	if !isTrustedIssuer(id.Issuer, verifierOpts) {
+		return fmt.Errorf("%w: %s", serrors.ErrorInvalidOIDCIssuer, id.Issuer)
+	}
+
+	// The issuer is the URL of the GitLab instance.
+	instance := strings.TrimSuffix(id.Issuer, "/") + "/"
+	if !strings.HasPrefix(id.SourceRepositoryURI, instance) {
+		return fmt.Errorf("%w: source '%s' is not on %s", serrors.ErrorMalformedURI,
+			id.SourceRepositoryURI, id.Issuer)
+	}
+
+	// The configuration URI is {project}//{path}@{ref}.
+	configPath := strings.SplitN(id.BuildConfigURI, "@", 2)
+	if len(configPath) < 2 {
+		return fmt.Errorf("%w: build config uri: %s", serrors.ErrorMalformedURI, id.BuildConfigURI)
+	}
+	if !strings.HasPrefix(configPath[0], id.SourceRepositoryURI+"//") {
+		return fmt.Errorf("%w: build config '%s' is not in project '%s'", serrors.ErrorUntrustedReusableWorkflow,
+			id.BuildConfigURI, id.SourceRepositoryURI)
+	}
+	if configPath[1] != id.SourceRef {
+		return fmt.Errorf("%w: build config ref '%s' != source ref '%s'", serrors.ErrorMismatchCertificate,
+			configPath[1], id.SourceRef)
+	}
+
+	// The pipeline that signed the provenance must be the top-level one.
+	if id.SubjectURI != id.BuildConfigURI {
+		return fmt.Errorf("%w: certificate subject '%s' != build config '%s'", serrors.ErrorMismatchCertificate,
+			id.SubjectURI, id.BuildConfigURI)
+	}
+	if id.BuildSignerURI != id.BuildConfigURI {
+		return fmt.Errorf("%w: build signer '%s' != build config '%s'", serrors.ErrorUntrustedReusableWorkflow,
+			id.BuildSignerURI, id.BuildConfigURI)
+	}
+	if err := validateClaimsEqual(id.SourceSha1, id.BuildConfigSha1); err != nil {
+		return err
+	}
+	if err := validateClaimsEqual(id.BuildConfigSha1, id.BuildSignerSha1); err != nil {
+		return err
+	}
+
+	// The job must belong to the project.
+	if !strings.HasPrefix(id.RunInvocationURI, id.SourceRepositoryURI+"/-/jobs/") {
+		return fmt.Errorf("%w: job '%s' is not in project '%s'", serrors.ErrorMismatchCertificate,
+			id.RunInvocationURI, id.SourceRepositoryURI)
+	}
+
+	return nil
+}
+
+func isTrustedIssuer(issuer string, verifierOpts *options.VerifierOpts) bool {
+	for _, i := range certOIDCIssuers(verifierOpts) {
+		if issuer == i {
+			return true
+		}
+	}
+	return false
+}
+
+func validateClaimsEqual(expected, actual string) error {
+	if expected != "" && actual != "" && expected != actual {
+		return fmt.Errorf("%w: '%v' != '%v'", serrors.ErrorMismatchCertificate, expected, actual)
+	}
+	return nil
+}
+
+// VerifyCertficateSourceRepository verifies the source repository.
+// The expected source may omit the scheme, e.g. gitlab.com/group/project.
+func VerifyCertficateSourceRepository(id *PipelineIdentity, sourceRepo string) error {
+	expectedSource := strings.TrimPrefix(sourceRepo, "git+")
+	if !strings.HasPrefix(expectedSource, "https://") {
+		expectedSource = "https://" + expectedSource
+	}
+	expectedSource = strings.TrimSuffix(expectedSource, ".git")
+	if id.SourceRepositoryURI != expectedSource {
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchSource,
+			Check:    utils.CheckSourceURI,
+			Field:    "certificate source repository URI",
+			Expected: expectedSource,
+			Actual:   id.SourceRepositoryURI,
+		}
+	}
+	return nil
+}
+
+// VerifyCertificateRef verifies the branch or tag the pipeline ran on.
+func VerifyCertificateRef(id *PipelineIdentity, provenanceOpts *options.ProvenanceOpts) error {
+	if provenanceOpts.ExpectedBranch != nil {
+		branch, err := utils.BranchFromGitRef(id.SourceRef)
+		if err != nil {
+			return fmt.Errorf("%w: %s", serrors.ErrorMismatchBranch, err)
+		}
+		if branch != *provenanceOpts.ExpectedBranch {
+			return &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchBranch,
+				Check:    utils.CheckBranch,
+				Field:    "certificate source ref",
+				Expected: *provenanceOpts.ExpectedBranch,
+				Actual:   branch,
+			}
+		}
+	}
+
+	if provenanceOpts.ExpectedTag != nil || provenanceOpts.ExpectedVersionedTag != nil {
+		tag, err := utils.TagFromGitRef(id.SourceRef)
+		if err != nil {
+			return fmt.Errorf("%w: %s", serrors.ErrorMismatchTag, err)
+		}
+		if provenanceOpts.ExpectedTag != nil && tag != *provenanceOpts.ExpectedTag {
+			return &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchTag,
+				Check:    utils.CheckTag,
+				Field:    "certificate source ref",
+				Expected: *provenanceOpts.ExpectedTag,
+				Actual:   tag,
+			}
+		}
+		if provenanceOpts.ExpectedVersionedTag != nil {
+			if err := utils.VerifyVersionedTag(tag, *provenanceOpts.ExpectedVersionedTag); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// VerifyBuilderIdentity verifies the expected builder ID against the
+// builder of the job, which is identified by the runner environment in
+// the certificate. The builder ID in the provenance is written by the job
+// itself, so it is not trusted.
+func VerifyBuilderIdentity(id *PipelineIdentity, builderOpts *options.BuilderOpts) (*utils.TrustedBuilderID, error) {
+	// Users must always provide the builder ID, since it selects
+	// the GitLab verifier.
+	if builderOpts == nil || builderOpts.ExpectedID == nil || *builderOpts.ExpectedID == "" {
+		return nil, fmt.Errorf("%w: empty ID", serrors.ErrorInvalidBuilderID)
+	}
+
+	var builderID string
+	switch id.RunnerEnvironment {
+	case runnerGitLabHosted:
+		builderID = builderGitLabHostedRunnerID
+	case runnerSelfHosted:
+		builderID = builderSelfHostedRunnerID
+	default:
+		return nil, fmt.Errorf("%w: runner environment '%s'", serrors.ErrorNotSupported, id.RunnerEnvironment)
+	}
+
+	switch *builderOpts.ExpectedID {
+	case builderGitLabHostedRunnerID, builderSelfHostedRunnerID:
+	default:
+		return nil, fmt.Errorf("%w: builder %v. Expected one of %v, %v", serrors.ErrorNotSupported, *builderOpts.ExpectedID,
+			builderGitLabHostedRunnerID, builderSelfHostedRunnerID)
+	}
+	if *builderOpts.ExpectedID != builderID {
+		return nil, &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchBuilderID,
+			Check:    utils.CheckBuilderID,
+			Field:    "certificate runner environment",
+			Expected: *builderOpts.ExpectedID,
+			Actual:   builderID,
+		}
+	}
+	return utils.TrustedBuilderIDNew(builderID, false)
+}
@@ -0,0 +1,346 @@
+package gitlab
+
+import (
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	fulcio "github.com/sigstore/fulcio/pkg/certificate"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+const (
+	testProject = "https://gitlab.com/group/project"
+	testConfig  = testProject + "//.gitlab-ci.yml@refs/heads/main"
+	testSha1    = "0123456789abcdef0123456789abcdef01234567"
+	testJob     = testProject + "/-/jobs/42"
+)
+
+func testIdentity() *PipelineIdentity {
+	return &PipelineIdentity{
+		Issuer:              "https://gitlab.com",
+		SubjectURI:          testConfig,
+		SourceRepositoryURI: testProject,
+		SourceSha1:          testSha1,
+		SourceRef:           "refs/heads/main",
+		SourceID:            "1234",
+		SourceOwnerURI:      "https://gitlab.com/group",
+		SourceOwnerID:       "5678",
+		BuildSignerURI:      testConfig,
+		BuildSignerSha1:     testSha1,
+		BuildConfigURI:      testConfig,
+		BuildConfigSha1:     testSha1,
+		BuildTrigger:        "push",
+		RunInvocationURI:    testJob,
+		RunnerEnvironment:   runnerGitLabHosted,
+	}
+}
+
+// testCertificate returns a certificate with the extensions of the identity.
+func testCertificate(t *testing.T, id *PipelineIdentity) *x509.Certificate {
+	t.Helper()
+	exts, err := fulcio.Extensions{
+		Issuer:                          id.Issuer,
+		BuildSignerURI:                  id.BuildSignerURI,
+		BuildSignerDigest:               id.BuildSignerSha1,
+		RunnerEnvironment:               id.RunnerEnvironment,
+		SourceRepositoryURI:             id.SourceRepositoryURI,
+		SourceRepositoryDigest:          id.SourceSha1,
+		SourceRepositoryRef:             id.SourceRef,
+		SourceRepositoryIdentifier:      id.SourceID,
+		SourceRepositoryOwnerURI:        id.SourceOwnerURI,
+		SourceRepositoryOwnerIdentifier: id.SourceOwnerID,
+		BuildConfigURI:                  id.BuildConfigURI,
+		BuildConfigDigest:               id.BuildConfigSha1,
+		BuildTrigger:                    id.BuildTrigger,
+		RunInvocationURI:                id.RunInvocationURI,
+	}.Render()
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	cert := &x509.Certificate{Extensions: exts}
+	if id.SubjectURI != "" {
+		u, err := url.Parse(id.SubjectURI)
+		if err != nil {
+			t.Fatalf("url.Parse: %v", err)
+		}
+		cert.URIs = []*url.URL{u}
+	}
+	return cert
+}
+
+func Test_GetPipelineInfoFromCertificate(t *testing.T) {
+	t.Parallel()
+
+	noRef := testIdentity()
+	noRef.SourceRef = ""
+	noURI := testIdentity()
+	noURI.SubjectURI = ""
+
+	tests := []struct {
+		name     string
+		id       *PipelineIdentity
+		expected error
+	}{
+		{
+			name: "valid certificate",
+			id:   testIdentity(),
+		},
+		{
+			name:     "missing ref",
+			id:       noRef,
+			expected: serrors.ErrorInvalidFormat,
+		},
+		{
+			name:     "missing URI",
+			id:       noURI,
+			expected: serrors.ErrorInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := GetPipelineInfoFromCertificate(testCertificate(t, tt.id))
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.id, got); diff != "" {
+				t.Errorf("unexpected identity (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_VerifyPipelineIdentity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		update       func(*PipelineIdentity)
+		verifierOpts *options.VerifierOpts
+		expected     error
+	}{
+		{
+			name:   "valid identity",
+			update: func(*PipelineIdentity) {},
+		},
+		{
+			name: "untrusted issuer",
+			update: func(id *PipelineIdentity) {
+				id.Issuer = "https://gitlab.example.com"
+			},
+			expected: serrors.ErrorInvalidOIDCIssuer,
+		},
+		{
+			name: "self-managed instance",
+			update: func(id *PipelineIdentity) {
+				id.Issuer = "https://gitlab.example.com"
+				id.SourceRepositoryURI = "https://gitlab.example.com/group/project"
+				id.BuildConfigURI = "https://gitlab.example.com/group/project//.gitlab-ci.yml@refs/heads/main"
+				id.BuildSignerURI = id.BuildConfigURI
+				id.SubjectURI = id.BuildConfigURI
+				id.RunInvocationURI = "https://gitlab.example.com/group/project/-/jobs/42"
+			},
+			verifierOpts: &options.VerifierOpts{
+				CertOIDCIssuers: []string{"https://gitlab.example.com"},
+			},
+		},
+		{
+			name: "source on other instance",
+			update: func(id *PipelineIdentity) {
+				id.SourceRepositoryURI = "https://gitlab.example.com/group/project"
+			},
+			expected: serrors.ErrorMalformedURI,
+		},
+		{
+			name: "config in other project",
+			update: func(id *PipelineIdentity) {
+				id.BuildConfigURI = "https://gitlab.com/other/project//.gitlab-ci.yml@refs/heads/main"
+				id.BuildSignerURI = id.BuildConfigURI
+				id.SubjectURI = id.BuildConfigURI
+			},
+			expected: serrors.ErrorUntrustedReusableWorkflow,
+		},
+		{
+			name: "config without ref",
+			update: func(id *PipelineIdentity) {
+				id.BuildConfigURI = testProject + "//.gitlab-ci.yml"
+			},
+			expected: serrors.ErrorMalformedURI,
+		},
+		{
+			name: "config ref mismatch",
+			update: func(id *PipelineIdentity) {
+				id.SourceRef = "refs/heads/dev"
+			},
+			expected: serrors.ErrorMismatchCertificate,
+		},
+		{
+			name: "subject mismatch",
+			update: func(id *PipelineIdentity) {
+				id.SubjectURI = testProject + "//other.yml@refs/heads/main"
+			},
+			expected: serrors.ErrorMismatchCertificate,
+		},
+		{
+			name: "included signer",
+			update: func(id *PipelineIdentity) {
+				id.BuildSignerURI = testProject + "//templates/build.yml@refs/heads/main"
+			},
+			expected: serrors.ErrorUntrustedReusableWorkflow,
+		},
+		{
+			name: "config digest mismatch",
+			update: func(id *PipelineIdentity) {
+				id.BuildConfigSha1 = "fedcba9876543210fedcba9876543210fedcba98"
+			},
+			expected: serrors.ErrorMismatchCertificate,
+		},
+		{
+			name: "job in other project",
+			update: func(id *PipelineIdentity) {
+				id.RunInvocationURI = "https://gitlab.com/other/project/-/jobs/42"
+			},
+			expected: serrors.ErrorMismatchCertificate,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := testIdentity()
+			tt.update(id)
+			err := VerifyPipelineIdentity(id, tt.verifierOpts)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func Test_VerifyCertficateSourceRepository(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		source   string
+		expected error
+	}{
+		{
+			name:   "no scheme",
+			source: "gitlab.com/group/project",
+		},
+		{
+			name:   "https",
+			source: "https://gitlab.com/group/project",
+		},
+		{
+			name:   "git+https with suffix",
+			source: "git+https://gitlab.com/group/project.git",
+		},
+		{
+			name:     "other project",
+			source:   "gitlab.com/group/other",
+			expected: serrors.ErrorMismatchSource,
+		},
+		{
+			name:     "github",
+			source:   "github.com/group/project",
+			expected: serrors.ErrorMismatchSource,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyCertficateSourceRepository(testIdentity(), tt.source)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func Test_VerifyCertificateRef(t *testing.T) {
+	t.Parallel()
+
+	main := "main"
+	dev := "dev"
+	tag := "v1.2.3"
+	versionedTag := "v1.2"
+
+	tests := []struct {
+		name     string
+		ref      string
+		opts     options.ProvenanceOpts
+		expected error
+	}{
+		{
+			name: "no expected ref",
+			ref:  "refs/heads/main",
+		},
+		{
+			name: "branch",
+			ref:  "refs/heads/main",
+			opts: options.ProvenanceOpts{ExpectedBranch: &main},
+		},
+		{
+			name:     "branch mismatch",
+			ref:      "refs/heads/main",
+			opts:     options.ProvenanceOpts{ExpectedBranch: &dev},
+			expected: serrors.ErrorMismatchBranch,
+		},
+		{
+			name:     "branch on tag pipeline",
+			ref:      "refs/tags/v1.2.3",
+			opts:     options.ProvenanceOpts{ExpectedBranch: &main},
+			expected: serrors.ErrorMismatchBranch,
+		},
+		{
+			name: "tag",
+			ref:  "refs/tags/v1.2.3",
+			opts: options.ProvenanceOpts{ExpectedTag: &tag},
+		},
+		{
+			name:     "tag on branch pipeline",
+			ref:      "refs/heads/main",
+			opts:     options.ProvenanceOpts{ExpectedTag: &tag},
+			expected: serrors.ErrorMismatchTag,
+		},
+		{
+			name: "versioned tag",
+			ref:  "refs/tags/v1.2.3",
+			opts: options.ProvenanceOpts{ExpectedVersionedTag: &versionedTag},
+		},
+		{
+			name:     "versioned tag mismatch",
+			ref:      "refs/tags/v1.3.0",
+			opts:     options.ProvenanceOpts{ExpectedVersionedTag: &versionedTag},
+			expected: serrors.ErrorMismatchVersionedTag,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := testIdentity()
+			id.SourceRef = tt.ref
+			err := VerifyCertificateRef(id, &tt.opts)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
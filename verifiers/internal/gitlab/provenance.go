@@ -0,0 +1,102 @@
+package gitlab
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsa02 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+// provenance is a SLSA provenance statement generated on GitLab CI.
+// Only the fields that are verified are parsed, since the build type
+// and parameters depend on the runner version.
+type provenance struct {
+	intoto.StatementHeader
+	builderID string
+}
+
+type provenanceStatement struct {
+	intoto.StatementHeader
+	Predicate json.RawMessage `json:"predicate"`
+}
+
+// provenanceFromEnv parses the provenance in the DSSE envelope.
+// The statement may be an in-toto v0.1 or v1 statement, with a SLSA v0.2
+// or v1.0 provenance predicate.
+func provenanceFromEnv(env *dsselib.Envelope) (*provenance, error) {
+	if env.PayloadType != intoto.PayloadType {
+		return nil, fmt.Errorf("%w: expected payload type '%s', got '%s'",
+			serrors.ErrorInvalidDssePayload, intoto.PayloadType, env.PayloadType)
+	}
+
+	pyld, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+	}
+
+	var statement provenanceStatement
+	if err := json.Unmarshal(pyld, &statement); err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+	}
+	if statement.Type != intoto.StatementInTotoV01 && statement.Type != utils.StatementInTotoV1 {
+		return nil, fmt.Errorf("%w: unexpected statement type '%s'", serrors.ErrorInvalidDssePayload, statement.Type)
+	}
+
+	prov := &provenance{StatementHeader: statement.StatementHeader}
+	switch statement.PredicateType {
+	case slsa02.PredicateSLSAProvenance:
+		var pred slsa02.ProvenancePredicate
+		if err := json.Unmarshal(statement.Predicate, &pred); err != nil {
+			return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+		}
+		prov.builderID = pred.Builder.ID
+	case slsa1.PredicateSLSAProvenance:
+		var pred slsa1.ProvenancePredicate
+		if err := json.Unmarshal(statement.Predicate, &pred); err != nil {
+			return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+		}
+		prov.builderID = pred.RunDetails.Builder.ID
+	default:
+		return nil, fmt.Errorf("%w: unexpected predicate type '%s'",
+			serrors.ErrorInvalidDssePayload, statement.PredicateType)
+	}
+
+	if prov.builderID == "" {
+		return nil, fmt.Errorf("%w: empty builder ID", serrors.ErrorInvalidDssePayload)
+	}
+	return prov, nil
+}
+
+// verifyDigest verifies that one of the subjects has the expected
+// sha256 digest.
+func (p *provenance) verifyDigest(expectedHash string) error {
+	if len(p.Subject) == 0 {
+		return fmt.Errorf("%w: no subjects", serrors.ErrorInvalidDssePayload)
+	}
+	var digests []string
+	for _, subject := range p.Subject {
+		digest, ok := subject.Digest["sha256"]
+		if !ok {
+			continue
+		}
+		if digest == expectedHash {
+			return nil
+		}
+		digests = append(digests, digest)
+	}
+	return &serrors.VerificationError{
+		Err:      serrors.ErrorMismatchHash,
+		Check:    utils.CheckSubjectDigest,
+		Field:    "subject.digest.sha256",
+		Expected: expectedHash,
+		Actual:   strings.Join(digests, ","),
+	}
+}
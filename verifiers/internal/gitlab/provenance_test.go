@@ -0,0 +1,101 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+)
+
+func Test_provenanceFromEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		env               *dsselib.Envelope
+		expectedBuilderID string
+		expected          error
+	}{
+		{
+			name:              "slsa v1",
+			env:               testEnvelope(testStatementV1),
+			expectedBuilderID: testBuilderID,
+		},
+		{
+			name: "slsa v0.2",
+			env: testEnvelope(`{
+				"_type": "https://in-toto.io/Statement/v0.1",
+				"subject": [{"name": "binary", "digest": {"sha256": "` + testDigest + `"}}],
+				"predicateType": "https://slsa.dev/provenance/v0.2",
+				"predicate": {"builder": {"id": "` + testBuilderID + `"}, "buildType": "https://gitlab.com/gitlab-org/gitlab-runner/-/blob/v16.0.0/PROVENANCE.md"}
+			}`),
+			expectedBuilderID: testBuilderID,
+		},
+		{
+			name: "invalid payload type",
+			env: &dsselib.Envelope{
+				PayloadType: "application/json",
+				Payload:     testEnvelope(testStatementV1).Payload,
+			},
+			expected: serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name: "invalid encoding",
+			env: &dsselib.Envelope{
+				PayloadType: testEnvelope(testStatementV1).PayloadType,
+				Payload:     "not base64",
+			},
+			expected: serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name: "invalid statement type",
+			env: testEnvelope(`{
+				"_type": "https://in-toto.io/Statement/v2",
+				"predicateType": "https://slsa.dev/provenance/v1",
+				"predicate": {"runDetails": {"builder": {"id": "` + testBuilderID + `"}}}
+			}`),
+			expected: serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name: "invalid predicate type",
+			env: testEnvelope(`{
+				"_type": "https://in-toto.io/Statement/v1",
+				"predicateType": "https://slsa.dev/verification_summary/v1",
+				"predicate": {}
+			}`),
+			expected: serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name: "empty builder ID",
+			env: testEnvelope(`{
+				"_type": "https://in-toto.io/Statement/v1",
+				"predicateType": "https://slsa.dev/provenance/v1",
+				"predicate": {"runDetails": {"builder": {"id": ""}}}
+			}`),
+			expected: serrors.ErrorInvalidDssePayload,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prov, err := provenanceFromEnv(tt.env)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+			if prov.builderID != tt.expectedBuilderID {
+				t.Errorf("expected builder ID '%s', got '%s'", tt.expectedBuilderID, prov.builderID)
+			}
+			if err := prov.verifyDigest(testDigest); err != nil {
+				t.Errorf("verifyDigest: %v", err)
+			}
+		})
+	}
+}
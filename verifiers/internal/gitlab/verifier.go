@@ -0,0 +1,267 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/rekor/pkg/generated/models"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/register"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils/container"
+)
+
+const VerifierName = "GitLab"
+
+//nolint:gochecknoinits
+func init() {
+	register.RegisterVerifier(VerifierName, GitLabVerifierNew())
+}
+
+type GitLabVerifier struct{}
+
+func GitLabVerifierNew() *GitLabVerifier {
+	return &GitLabVerifier{}
+}
+
+// IsAuthoritativeFor returns true of the verifier can verify provenance
+// generated by the builderID.
+func (v *GitLabVerifier) IsAuthoritativeFor(builderID string) bool {
+	// This verifier only supports builders defined on GitLab.
+	return strings.HasPrefix(builderID, httpsGitlabCom)
+}
+
+func verifyEnvAndCert(ctx context.Context, env *dsse.Envelope,
+	cert *x509.Certificate,
+	tlogEntry *utils.TransparencyLogEntry,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	/* Verify properties of the signing identity. */
+	// Get the pipeline info given the certificate information.
+	pipelineInfo, err := GetPipelineInfoFromCertificate(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the pipeline identity.
+	if err := VerifyPipelineIdentity(pipelineInfo, verifierOpts); err != nil {
+		return nil, err
+	}
+
+	// Verify the source repository and ref from the certificate.
+	if err := VerifyCertficateSourceRepository(pipelineInfo, provenanceOpts.ExpectedSourceURI); err != nil {
+		return nil, err
+	}
+	if err := VerifyCertificateRef(pipelineInfo, provenanceOpts); err != nil {
+		return nil, err
+	}
+	// Pipeline inputs are not recorded in the certificate.
+	if len(provenanceOpts.ExpectedWorkflowInputs) > 0 {
+		return nil, fmt.Errorf("%w: workflow inputs for GitLab CI builders", serrors.ErrorNotSupported)
+	}
+
+	// Verify the builder from the certificate.
+	builderID, err := VerifyBuilderIdentity(pipelineInfo, builderOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify properties of the SLSA provenance.
+	// Unpack and verify info in the provenance, including the subject Digest.
+	prov, err := provenanceFromEnv(env)
+	if err != nil {
+		return nil, err
+	}
+	if err := prov.verifyDigest(provenanceOpts.ExpectedDigest); err != nil {
+		return nil, err
+	}
+
+	events.Emit(ctx, events.BuildVerified{
+		BuilderID:    builderID.String(),
+		SourceCommit: pipelineInfo.SourceSha1,
+	})
+	// Return verified provenance.
+	r, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &utils.VerificationResult{
+		Statement:            r,
+		BuilderID:            builderID,
+		SourceURI:            pipelineInfo.SourceRepositoryURI,
+		SourceCommit:         pipelineInfo.SourceSha1,
+		WorkflowIdentity:     pipelineInfo.result(),
+		TransparencyLogEntry: tlogEntry,
+		BuildLevel:           buildLevel(pipelineInfo),
+	}
+	result.AddChecks(utils.CheckSignature)
+	if tlogEntry != nil {
+		result.AddChecks(utils.CheckTransparencyLog)
+	}
+	result.AddChecks(utils.CheckBuilderID, utils.CheckSourceURI, utils.CheckSubjectDigest)
+	if provenanceOpts.ExpectedBranch != nil {
+		result.AddChecks(utils.CheckBranch)
+	}
+	if provenanceOpts.ExpectedTag != nil {
+		result.AddChecks(utils.CheckTag)
+	}
+	if provenanceOpts.ExpectedVersionedTag != nil {
+		result.AddChecks(utils.CheckVersionedTag)
+	}
+	return result, nil
+}
+
+// buildLevel returns the SLSA build level achieved by a GitLab CI job.
+// The provenance is generated by the job itself, so it is SLSA Build L2
+// on a GitLab-hosted runner, and SLSA Build L1 on a self-hosted runner.
+func buildLevel(id *PipelineIdentity) int {
+	if id.RunnerEnvironment == runnerGitLabHosted {
+		return 2
+	}
+	return 1
+}
+
+// VerifyArtifact verifies provenance for an artifact.
+// The provenance may be in the JSON Lines format, with one attestation per
+// line. In that case, each attestation is verified in turn and verification
+// succeeds with the first one that verifies.
+func (v *GitLabVerifier) VerifyArtifact(ctx context.Context,
+	provenance []byte, artifactHash string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	verifierOpts = gitlabVerifierOpts(verifierOpts)
+	return gha.VerifyProvenanceLines(ctx, provenance,
+		func(attestation []byte) (*utils.VerificationResult, error) {
+			signedAtt, err := gha.VerifyArtifactSignature(ctx, attestation, artifactHash, verifierOpts)
+			if err != nil {
+				return nil, err
+			}
+			return verifyEnvAndCert(ctx, signedAtt.Envelope, signedAtt.SigningCert,
+				gha.TlogEntryResult(signedAtt.RekorEntry, verifierOpts),
+				provenanceOpts, builderOpts, verifierOpts)
+		})
+}
+
+// VerifyArtifacts verifies provenance for several artifacts covered by the
+// same provenance. The signature of each attestation in the provenance is
+// verified once, then the properties of the provenance, including the subject
+// digest, are verified for each artifact. An artifact passes verification if
+// it passes with any of the attestations.
+func (v *GitLabVerifier) VerifyArtifacts(ctx context.Context,
+	provenance []byte, artifactHashes []string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) ([]utils.ArtifactResult, error) {
+	verifierOpts = gitlabVerifierOpts(verifierOpts)
+	return gha.VerifyArtifactsProvenanceLines(ctx, provenance, artifactHashes, verifierOpts,
+		func(signedAtt *gha.SignedAttestation, artifactHash string) (*utils.VerificationResult, error) {
+			// The options are updated during verification.
+			opts := *provenanceOpts
+			opts.ExpectedDigest = artifactHash
+			return verifyEnvAndCert(ctx, signedAtt.Envelope, signedAtt.SigningCert,
+				gha.TlogEntryResult(signedAtt.RekorEntry, verifierOpts),
+				&opts, builderOpts, verifierOpts)
+		})
+}
+
+// VerifyImage verifies provenance for an OCI image.
+func (v *GitLabVerifier) VerifyImage(ctx context.Context,
+	provenance []byte, artifactImage string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	if err := gha.CheckImageVerifierOpts(verifierOpts); err != nil {
+		return nil, err
+	}
+	verifierOpts = gitlabVerifierOpts(verifierOpts)
+
+	/* Retrieve any valid signed attestations that chain up to Fulcio root CA. */
+	trustedRoot, err := gha.TrustedRootWithOpts(ctx, verifierOpts)
+	if err != nil {
+		return nil, err
+	}
+	opts := &cosign.CheckOpts{
+		RootCerts:         trustedRoot.FulcioRoot,
+		IntermediateCerts: trustedRoot.FulcioIntermediates,
+		RekorPubKeys:      trustedRoot.RekorPubKeys,
+		CTLogPubKeys:      trustedRoot.CTPubKeys,
+		Identities:        gha.CertIdentities(verifierOpts),
+	}
+
+	atts, _, err := container.RunCosignImageVerification(ctx,
+		artifactImage, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	/* Now verify properties of the attestations */
+	var errs []error
+	for _, att := range atts {
+		pyld, err := att.Payload()
+		if err != nil {
+			events.Emit(ctx, events.Warning{Message: fmt.Sprintf("unexpected error getting payload from OCI registry %s", err)})
+			continue
+		}
+		env, err := gha.EnvelopeFromBytes(pyld)
+		if err != nil {
+			events.Emit(ctx, events.Warning{Message: fmt.Sprintf("unexpected error parsing envelope from OCI registry %s", err)})
+			continue
+		}
+		cert, err := att.Cert()
+		if err != nil {
+			events.Emit(ctx, events.Warning{Message: fmt.Sprintf("unexpected error getting certificate from OCI registry %s", err)})
+			continue
+		}
+		// The Rekor bundle has been verified by cosign.
+		var tlogEntry *utils.TransparencyLogEntry
+		if bundle, err := att.Bundle(); err == nil && bundle != nil {
+			tlogEntry = gha.TlogEntryResult(&models.LogEntryAnon{
+				LogID:          &bundle.Payload.LogID,
+				LogIndex:       &bundle.Payload.LogIndex,
+				IntegratedTime: &bundle.Payload.IntegratedTime,
+			}, verifierOpts)
+		}
+		result, err := verifyEnvAndCert(ctx, env,
+			cert, tlogEntry, provenanceOpts, builderOpts, verifierOpts)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, err)
+	}
+
+	// Return the first error.
+	if len(errs) > 0 {
+		var s string
+		if len(errs) > 1 {
+			s = fmt.Sprintf(": %v", errs[1:])
+		}
+		return nil, fmt.Errorf("%w%s", errs[0], s)
+	}
+	return nil, fmt.Errorf("%w", serrors.ErrorNoValidSignature)
+}
+
+// VerifyNpmPackage verifies an npm package tarball.
+func (v *GitLabVerifier) VerifyNpmPackage(ctx context.Context,
+	attestations []byte, tarballHash string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
+	return nil, fmt.Errorf("%w: npm packages built on GitLab CI", serrors.ErrorNotSupported)
+}
@@ -0,0 +1,203 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+)
+
+const (
+	testBuilderID = "https://gitlab.com/group/project/-/runners/12"
+	testDigest    = "8f3bd4a1d7e3b1c4e1b0d7c1b8a3f6e2d5c4b3a29180f7e6d5c4b3a291807f6e"
+)
+
+func testEnvelope(statement string) *dsselib.Envelope {
+	return &dsselib.Envelope{
+		PayloadType: intoto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString([]byte(statement)),
+	}
+}
+
+const testStatementV1 = `{
+	"_type": "https://in-toto.io/Statement/v1",
+	"subject": [{"name": "binary", "digest": {"sha256": "` + testDigest + `"}}],
+	"predicateType": "https://slsa.dev/provenance/v1",
+	"predicate": {
+		"buildDefinition": {"buildType": "https://gitlab.com/gitlab-org/gitlab-runner/-/blob/v16.8.0/PROVENANCE.md"},
+		"runDetails": {"builder": {"id": "` + testBuilderID + `"}}
+	}
+}`
+
+func Test_IsAuthoritativeFor(t *testing.T) {
+	t.Parallel()
+
+	v := GitLabVerifierNew()
+	for _, builderID := range []string{builderGitLabHostedRunnerID, builderSelfHostedRunnerID} {
+		if !v.IsAuthoritativeFor(builderID) {
+			t.Errorf("expected GitLab builder %s to be supported", builderID)
+		}
+	}
+	if v.IsAuthoritativeFor("https://github.com/slsa-framework/slsa-github-generator/.github/workflows/builder_go_slsa3.yml") {
+		t.Errorf("expected GitHub builder not to be supported")
+	}
+}
+
+func Test_verifyEnvAndCert(t *testing.T) {
+	t.Parallel()
+
+	builderID := builderGitLabHostedRunnerID
+	selfHostedBuilderID := builderSelfHostedRunnerID
+	// The builder ID in the provenance is not trusted.
+	provenanceBuilderID := testBuilderID
+	empty := ""
+	branch := "main"
+	selfHosted := testIdentity()
+	selfHosted.RunnerEnvironment = runnerSelfHosted
+	unknownRunner := testIdentity()
+	unknownRunner.RunnerEnvironment = ""
+
+	tests := []struct {
+		name           string
+		id             *PipelineIdentity
+		builderID      *string
+		source         string
+		branch         *string
+		inputs         map[string]string
+		digest         string
+		expected       error
+		expectedID     string
+		expectedLevel  int
+		expectedChecks []string
+	}{
+		{
+			name:           "valid provenance",
+			builderID:      &builderID,
+			source:         "gitlab.com/group/project",
+			branch:         &branch,
+			digest:         testDigest,
+			expectedID:     builderGitLabHostedRunnerID,
+			expectedLevel:  2,
+			expectedChecks: []string{"signature", "builder-id", "source-uri", "subject-digest", "branch"},
+		},
+		{
+			name:           "self-hosted runner",
+			id:             selfHosted,
+			builderID:      &selfHostedBuilderID,
+			source:         "gitlab.com/group/project",
+			digest:         testDigest,
+			expectedID:     builderSelfHostedRunnerID,
+			expectedLevel:  1,
+			expectedChecks: []string{"signature", "builder-id", "source-uri", "subject-digest"},
+		},
+		{
+			name:     "no builder ID",
+			source:   "gitlab.com/group/project",
+			digest:   testDigest,
+			expected: serrors.ErrorInvalidBuilderID,
+		},
+		{
+			name:      "empty builder ID",
+			builderID: &empty,
+			source:    "gitlab.com/group/project",
+			digest:    testDigest,
+			expected:  serrors.ErrorInvalidBuilderID,
+		},
+		{
+			name:      "mismatch builder ID",
+			builderID: &selfHostedBuilderID,
+			source:    "gitlab.com/group/project",
+			digest:    testDigest,
+			expected:  serrors.ErrorMismatchBuilderID,
+		},
+		{
+			name:      "mismatch runner environment",
+			id:        selfHosted,
+			builderID: &builderID,
+			source:    "gitlab.com/group/project",
+			digest:    testDigest,
+			expected:  serrors.ErrorMismatchBuilderID,
+		},
+		{
+			name:      "unknown runner environment",
+			id:        unknownRunner,
+			builderID: &builderID,
+			source:    "gitlab.com/group/project",
+			digest:    testDigest,
+			expected:  serrors.ErrorNotSupported,
+		},
+		{
+			name:      "provenance builder ID",
+			builderID: &provenanceBuilderID,
+			source:    "gitlab.com/group/project",
+			digest:    testDigest,
+			expected:  serrors.ErrorNotSupported,
+		},
+		{
+			name:      "mismatch source",
+			builderID: &builderID,
+			source:    "gitlab.com/group/other",
+			digest:    testDigest,
+			expected:  serrors.ErrorMismatchSource,
+		},
+		{
+			name:      "mismatch digest",
+			builderID: &builderID,
+			source:    "gitlab.com/group/project",
+			digest:    "0000000000000000000000000000000000000000000000000000000000000000",
+			expected:  serrors.ErrorMismatchHash,
+		},
+		{
+			name:      "workflow inputs",
+			builderID: &builderID,
+			source:    "gitlab.com/group/project",
+			inputs:    map[string]string{"release": "true"},
+			digest:    testDigest,
+			expected:  serrors.ErrorNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := tt.id
+			if id == nil {
+				id = testIdentity()
+			}
+			result, err := verifyEnvAndCert(context.Background(), testEnvelope(testStatementV1), testCertificate(t, id), nil,
+				&options.ProvenanceOpts{
+					ExpectedSourceURI:      tt.source,
+					ExpectedBranch:         tt.branch,
+					ExpectedWorkflowInputs: tt.inputs,
+					ExpectedDigest:         tt.digest,
+				},
+				&options.BuilderOpts{ExpectedID: tt.builderID}, nil)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+			if result.BuilderID.String() != tt.expectedID {
+				t.Errorf("unexpected builder ID: %s", result.BuilderID.String())
+			}
+			if result.SourceURI != testProject || result.SourceCommit != testSha1 {
+				t.Errorf("unexpected source: %s@%s", result.SourceURI, result.SourceCommit)
+			}
+			if result.BuildLevel != tt.expectedLevel {
+				t.Errorf("unexpected build level: %d", result.BuildLevel)
+			}
+			if diff := cmp.Diff(tt.expectedChecks, result.Checks); diff != "" {
+				t.Errorf("unexpected checks (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
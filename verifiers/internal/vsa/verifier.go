@@ -0,0 +1,114 @@
+package vsa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	register "github.com/slsa-framework/slsa-verifier/v2/register"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+const VerifierName = "VSA"
+
+//nolint:gochecknoinits
+func init() {
+	register.RegisterVSAVerifier(VerifierName, VSAVerifierNew())
+}
+
+type VSAVerifier struct{}
+
+func VSAVerifierNew() *VSAVerifier {
+	return &VSAVerifier{}
+}
+
+// IsAuthoritativeFor returns true of the verifier can verify VSAs
+// with the predicate type.
+func (v *VSAVerifier) IsAuthoritativeFor(predicateType string) bool {
+	return predicateType == utils.PredicateVSA
+}
+
+// VerifyVSA verifies a VSA for an artifact. The attestation may be
+// in the JSON Lines format, with one DSSE envelope per line. In that case,
+// verification succeeds with the first envelope that verifies.
+func (v *VSAVerifier) VerifyVSA(ctx context.Context,
+	attestation []byte,
+	vsaOpts *options.VSAOpts,
+) (*utils.VerificationResult, error) {
+	if vsaOpts == nil || vsaOpts.VerifierKey == nil {
+		return nil, fmt.Errorf("%w: empty verifier key", serrors.ErrorInvalidKey)
+	}
+
+	var lines [][]byte
+	for _, line := range bytes.Split(attestation, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: empty VSA", serrors.ErrorInvalidFormat)
+	}
+
+	// Return the first error if no envelope verifies.
+	var errs []error
+	for i, line := range lines {
+		result, err := verifyEnvelope(ctx, line, vsaOpts)
+		if err == nil {
+			if len(lines) > 1 {
+				result.ProvenanceLine = i + 1
+			}
+			return result, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	return nil, fmt.Errorf("%w: %v", errs[0], errs[1:])
+}
+
+// verifyEnvelope verifies the signature and the content of a VSA
+// in a DSSE envelope.
+func verifyEnvelope(ctx context.Context, b []byte, vsaOpts *options.VSAOpts) (*utils.VerificationResult, error) {
+	var env dsselib.Envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidDssePayload, err)
+	}
+
+	payload, keyID, err := utils.VerifyEnvelope(ctx, &env, vsaOpts.VerifierKey)
+	if err != nil {
+		return nil, err
+	}
+	if env.PayloadType != intoto.PayloadType {
+		return nil, fmt.Errorf("%w: expected payload type '%s', got '%s'",
+			serrors.ErrorInvalidDssePayload, intoto.PayloadType, env.PayloadType)
+	}
+
+	vsa, err := vsaFromPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := verifyVSA(vsa, vsaOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &utils.VerificationResult{
+		Statement:  payload,
+		SigningKey: keyID,
+		BuildLevel: level,
+	}
+	result.AddChecks(utils.CheckSignature, utils.CheckResourceURI, utils.CheckSubjectDigest)
+	if vsaOpts.ExpectedVerifierID != "" {
+		result.AddChecks(utils.CheckVerifierID)
+	}
+	result.AddChecks(utils.CheckVerificationPass, utils.CheckVerifiedLevels)
+	return result, nil
+}
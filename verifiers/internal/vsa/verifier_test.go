@@ -0,0 +1,198 @@
+package vsa
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+const (
+	testResourceURI = "binary-linux-amd64"
+	testDigest      = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+func signVSA(t *testing.T, key *ecdsa.PrivateKey, vsa *utils.VSA) []byte {
+	t.Helper()
+
+	return signVSAWithPayloadType(t, key, "application/vnd.in-toto+json", vsa)
+}
+
+func signVSAWithPayloadType(t *testing.T, key *ecdsa.PrivateKey, payloadType string, vsa *utils.VSA) []byte {
+	t.Helper()
+
+	keyPEM, err := cryptoutils.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKeyToPEM: %v", err)
+	}
+	payload, err := json.Marshal(vsa)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	env, err := utils.SignEnvelope(context.Background(), payloadType, payload, keyPEM)
+	if err != nil {
+		t.Fatalf("SignEnvelope: %v", err)
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	return b
+}
+
+func newTestVSA(t *testing.T, level int) *utils.VSA {
+	t.Helper()
+
+	vsa, err := utils.NewVSA(&utils.VerificationResult{BuildLevel: level}, &utils.VSAOpts{
+		ResourceURI:  testResourceURI,
+		Digest:       testDigest,
+		TimeVerified: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("NewVSA: %v", err)
+	}
+	return vsa
+}
+
+func Test_VerifyVSA(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	valid := signVSA(t, key, newTestVSA(t, 3))
+	failed := newTestVSA(t, 3)
+	failed.Predicate.VerificationResult = utils.VerificationFailed
+	wrongType := newTestVSA(t, 3)
+	wrongType.PredicateType = "https://slsa.dev/provenance/v1"
+	otherTrack := newTestVSA(t, 3)
+	otherTrack.Predicate.VerifiedLevels = []string{"SLSA_SOURCE_LEVEL_3"}
+
+	tests := []struct {
+		name        string
+		attestation []byte
+		opts        options.VSAOpts
+		level       int
+		line        int
+		expected    error
+	}{
+		{
+			name:        "valid",
+			attestation: valid,
+			level:       3,
+		},
+		{
+			name:        "valid with verifier ID",
+			attestation: valid,
+			opts:        options.VSAOpts{ExpectedVerifierID: utils.VerifierID},
+			level:       3,
+		},
+		{
+			name:        "valid on second line",
+			attestation: append(append(signVSA(t, otherKey, newTestVSA(t, 3)), '\n'), valid...),
+			level:       3,
+			line:        2,
+		},
+		{
+			name:        "untrusted key",
+			attestation: signVSA(t, otherKey, newTestVSA(t, 3)),
+			expected:    serrors.ErrorInvalidSignature,
+		},
+		{
+			name:        "mismatch resource URI",
+			attestation: valid,
+			opts:        options.VSAOpts{ExpectedResourceURI: "other"},
+			expected:    serrors.ErrorMismatchResourceURI,
+		},
+		{
+			name:        "mismatch digest",
+			attestation: valid,
+			opts:        options.VSAOpts{ExpectedDigest: "abcd"},
+			expected:    serrors.ErrorMismatchHash,
+		},
+		{
+			name:        "mismatch verifier ID",
+			attestation: valid,
+			opts:        options.VSAOpts{ExpectedVerifierID: "https://example.com/verifier"},
+			expected:    serrors.ErrorMismatchVerifierID,
+		},
+		{
+			name:        "failed verification",
+			attestation: signVSA(t, key, failed),
+			expected:    serrors.ErrorFailedVerification,
+		},
+		{
+			name:        "insufficient level",
+			attestation: signVSA(t, key, newTestVSA(t, 2)),
+			expected:    serrors.ErrorInsufficientLevel,
+		},
+		{
+			name:        "no build level",
+			attestation: signVSA(t, key, otherTrack),
+			expected:    serrors.ErrorInsufficientLevel,
+		},
+		{
+			name:        "invalid predicate type",
+			attestation: signVSA(t, key, wrongType),
+			expected:    serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name:        "invalid payload type",
+			attestation: signVSAWithPayloadType(t, key, "application/json", newTestVSA(t, 3)),
+			expected:    serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name:        "empty",
+			attestation: []byte("\n"),
+			expected:    serrors.ErrorInvalidFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := tt.opts
+			opts.VerifierKey = key.Public()
+			opts.MinimumBuildLevel = 3
+			if opts.ExpectedResourceURI == "" {
+				opts.ExpectedResourceURI = testResourceURI
+			}
+			if opts.ExpectedDigest == "" {
+				opts.ExpectedDigest = testDigest
+			}
+
+			result, err := VSAVerifierNew().VerifyVSA(context.Background(), tt.attestation, &opts)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+			if result.BuildLevel != tt.level {
+				t.Errorf(cmp.Diff(result.BuildLevel, tt.level))
+			}
+			if result.ProvenanceLine != tt.line {
+				t.Errorf(cmp.Diff(result.ProvenanceLine, tt.line))
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package vsa
+
+import (
+	"encoding/json"
+	"fmt"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/options"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+func vsaFromPayload(payload []byte) (*utils.VSA, error) {
+	var vsa utils.VSA
+	if err := json.Unmarshal(payload, &vsa); err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidDssePayload, err)
+	}
+	if vsa.Type != utils.StatementInTotoV1 {
+		return nil, fmt.Errorf("%w: expected statement header type '%s', got '%s'",
+			serrors.ErrorInvalidDssePayload, utils.StatementInTotoV1, vsa.Type)
+	}
+	if vsa.PredicateType != utils.PredicateVSA {
+		return nil, fmt.Errorf("%w: expected predicate type '%s', got '%s'",
+			serrors.ErrorInvalidDssePayload, utils.PredicateVSA, vsa.PredicateType)
+	}
+	return &vsa, nil
+}
+
+// verifyVSA verifies the content of the VSA against the options,
+// and returns the highest SLSA build level it verified.
+func verifyVSA(vsa *utils.VSA, vsaOpts *options.VSAOpts) (int, error) {
+	if err := verifyResourceURI(vsa, vsaOpts.ExpectedResourceURI); err != nil {
+		return 0, err
+	}
+
+	if err := verifySubjectDigest(vsa, vsaOpts.ExpectedDigest); err != nil {
+		return 0, err
+	}
+
+	if vsaOpts.ExpectedVerifierID != "" && vsa.Predicate.Verifier.ID != vsaOpts.ExpectedVerifierID {
+		return 0, fmt.Errorf("%w: expected '%s', got '%s'", serrors.ErrorMismatchVerifierID,
+			vsaOpts.ExpectedVerifierID, vsa.Predicate.Verifier.ID)
+	}
+
+	if vsa.Predicate.VerificationResult != utils.VerificationPassed {
+		return 0, fmt.Errorf("%w: got '%s'", serrors.ErrorFailedVerification,
+			vsa.Predicate.VerificationResult)
+	}
+
+	return verifyBuildLevel(vsa, vsaOpts.MinimumBuildLevel)
+}
+
+func verifyResourceURI(vsa *utils.VSA, expectedResourceURI string) error {
+	if expectedResourceURI == "" {
+		return fmt.Errorf("%w: empty resource URI", serrors.ErrorMismatchResourceURI)
+	}
+	if vsa.Predicate.ResourceURI != expectedResourceURI {
+		return fmt.Errorf("%w: expected '%s', got '%s'", serrors.ErrorMismatchResourceURI,
+			expectedResourceURI, vsa.Predicate.ResourceURI)
+	}
+	return nil
+}
+
+func verifySubjectDigest(vsa *utils.VSA, expectedHash string) error {
+	if expectedHash == "" {
+		return fmt.Errorf("%w: empty digest", serrors.ErrorInvalidHash)
+	}
+	for _, subject := range vsa.Subject {
+		if subject.Digest["sha256"] == expectedHash {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: expected hash '%s' not found", serrors.ErrorMismatchHash, expectedHash)
+}
+
+// verifyBuildLevel returns the highest SLSA build level verified by the VSA,
+// or an error if it is lower than minLevel.
+func verifyBuildLevel(vsa *utils.VSA, minLevel int) (int, error) {
+	level, found := 0, false
+	for _, name := range vsa.Predicate.VerifiedLevels {
+		if l, ok := utils.ParseBuildLevel(name); ok && (!found || l > level) {
+			level, found = l, true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: no build level in %v", serrors.ErrorInsufficientLevel,
+			vsa.Predicate.VerifiedLevels)
+	}
+	if level < minLevel {
+		return 0, fmt.Errorf("%w: expected %s, got %s", serrors.ErrorInsufficientLevel,
+			utils.BuildLevel(minLevel), utils.BuildLevel(level))
+	}
+	return level, nil
+}
@@ -0,0 +1,314 @@
+// Package provenance provides a read-only view of verified SLSA provenance.
+//
+// The provenance is parsed from the in-toto statement returned by a successful
+// verification, i.e. utils.VerificationResult.Statement. No signature or
+// policy check is performed by this package: callers must only pass
+// statements that have been verified.
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsa01 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.1"
+	slsa02 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha/slsaprovenance/iface"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+// Supported predicate types.
+const (
+	// PredicateSLSAProvenanceV01 is the SLSA v0.1 provenance predicate,
+	// generated by Google Cloud Build.
+	PredicateSLSAProvenanceV01 = slsa01.PredicateSLSAProvenance
+	// PredicateSLSAProvenanceV02 is the SLSA v0.2 provenance predicate.
+	PredicateSLSAProvenanceV02 = slsa02.PredicateSLSAProvenance
+	// PredicateSLSAProvenanceV1 is the SLSA v1.0 provenance predicate.
+	PredicateSLSAProvenanceV1 = slsa1.PredicateSLSAProvenance
+)
+
+// Material is an artifact the build depended on, i.e. a material in SLSA
+// v0.1 and v0.2, and a resolved dependency in SLSA v1.0.
+type Material struct {
+	URI    string
+	Digest map[string]string
+}
+
+// Provenance is a read-only view of a verified SLSA provenance statement.
+// All the returned values are copies, so modifying them does not modify
+// the provenance.
+type Provenance struct {
+	// statement is the verified in-toto statement.
+	statement []byte
+	header    intoto.StatementHeader
+
+	buildType    string
+	builderID    string
+	invocationID string
+	startedOn    *time.Time
+	finishedOn   *time.Time
+	materials    []Material
+
+	// github is the view of provenance generated by the GitHub builders.
+	// It is nil for other build types.
+	github iface.Provenance
+}
+
+// FromVerificationResult returns the provenance verified by a successful
+// verification.
+func FromVerificationResult(result *utils.VerificationResult) (*Provenance, error) {
+	if result == nil {
+		return nil, fmt.Errorf("%w: empty verification result", serrors.ErrorInvalidDssePayload)
+	}
+	return New(result.Statement)
+}
+
+// New returns the provenance in the verified in-toto statement.
+func New(statement []byte) (*Provenance, error) {
+	var header intoto.StatementHeader
+	if err := json.Unmarshal(statement, &header); err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+	}
+	if header.Type != intoto.StatementInTotoV01 && header.Type != utils.StatementInTotoV1 {
+		return nil, fmt.Errorf("%w: unexpected statement type '%s'", serrors.ErrorInvalidDssePayload, header.Type)
+	}
+
+	p := &Provenance{
+		statement: append([]byte(nil), statement...),
+		header:    header,
+	}
+	switch header.PredicateType {
+	case PredicateSLSAProvenanceV01:
+		pred, err := unmarshalPredicate[slsa01.ProvenancePredicate](statement)
+		if err != nil {
+			return nil, err
+		}
+		p.buildType = pred.Recipe.Type
+		p.builderID = pred.Builder.ID
+		if pred.Metadata != nil {
+			p.startedOn = pred.Metadata.BuildStartedOn
+			p.finishedOn = pred.Metadata.BuildFinishedOn
+		}
+		for _, m := range pred.Materials {
+			p.materials = append(p.materials, Material{URI: m.URI, Digest: m.Digest})
+		}
+	case PredicateSLSAProvenanceV02:
+		pred, err := unmarshalPredicate[slsa02.ProvenancePredicate](statement)
+		if err != nil {
+			return nil, err
+		}
+		p.buildType = pred.BuildType
+		p.builderID = pred.Builder.ID
+		if pred.Metadata != nil {
+			p.invocationID = pred.Metadata.BuildInvocationID
+			p.startedOn = pred.Metadata.BuildStartedOn
+			p.finishedOn = pred.Metadata.BuildFinishedOn
+		}
+		for _, m := range pred.Materials {
+			p.materials = append(p.materials, Material{URI: m.URI, Digest: m.Digest})
+		}
+	case PredicateSLSAProvenanceV1:
+		pred, err := unmarshalPredicate[slsa1.ProvenancePredicate](statement)
+		if err != nil {
+			return nil, err
+		}
+		p.buildType = pred.BuildDefinition.BuildType
+		p.builderID = pred.RunDetails.Builder.ID
+		p.invocationID = pred.RunDetails.BuildMetadata.InvocationID
+		p.startedOn = pred.RunDetails.BuildMetadata.StartedOn
+		p.finishedOn = pred.RunDetails.BuildMetadata.FinishedOn
+		for _, m := range pred.BuildDefinition.ResolvedDependencies {
+			p.materials = append(p.materials, Material{URI: m.URI, Digest: m.Digest})
+		}
+	default:
+		return nil, fmt.Errorf("%w: unexpected predicate type '%s'", serrors.ErrorInvalidDssePayload, header.PredicateType)
+	}
+
+	// The GitHub builders' provenance has fields specific to their build type.
+	// Other build types are still supported, without these fields.
+	if github, err := slsaprovenance.ProvenanceFromStatement(statement); err == nil {
+		p.github = github
+	}
+
+	return p, nil
+}
+
+func unmarshalPredicate[T any](statement []byte) (*T, error) {
+	s := struct {
+		Predicate T `json:"predicate"`
+	}{}
+	if err := json.Unmarshal(statement, &s); err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+	}
+	return &s.Predicate, nil
+}
+
+// Statement returns the verified in-toto statement.
+func (p *Provenance) Statement() []byte {
+	return append([]byte(nil), p.statement...)
+}
+
+// StatementType returns the type of the in-toto statement.
+func (p *Provenance) StatementType() string {
+	return p.header.Type
+}
+
+// PredicateType returns the predicate type, one of the supported
+// PredicateSLSAProvenance types.
+func (p *Provenance) PredicateType() string {
+	return p.header.PredicateType
+}
+
+// Subjects returns the subjects of the statement.
+func (p *Provenance) Subjects() []intoto.Subject {
+	subjects := make([]intoto.Subject, 0, len(p.header.Subject))
+	for _, s := range p.header.Subject {
+		subjects = append(subjects, intoto.Subject{
+			Name:   s.Name,
+			Digest: copyMap(s.Digest),
+		})
+	}
+	return subjects
+}
+
+// BuildType returns the build type, or the recipe type for SLSA v0.1.
+func (p *Provenance) BuildType() string {
+	return p.buildType
+}
+
+// BuilderID returns the builder ID.
+func (p *Provenance) BuilderID() string {
+	return p.builderID
+}
+
+// BuildInvocationID returns the build invocation ID, if any.
+// SLSA v0.1 provenance has no build invocation ID.
+func (p *Provenance) BuildInvocationID() string {
+	return p.invocationID
+}
+
+// BuildStartTime returns the time the build started, if known.
+func (p *Provenance) BuildStartTime() *time.Time {
+	return copyTime(p.startedOn)
+}
+
+// BuildFinishTime returns the time the build finished, if known.
+func (p *Provenance) BuildFinishTime() *time.Time {
+	return copyTime(p.finishedOn)
+}
+
+// Materials returns the materials, or resolved dependencies, of the build.
+func (p *Provenance) Materials() []Material {
+	materials := make([]Material, 0, len(p.materials))
+	for _, m := range p.materials {
+		materials = append(materials, Material{
+			URI:    m.URI,
+			Digest: copyMap(m.Digest),
+		})
+	}
+	return materials
+}
+
+// SourceURI returns the URI of the source, i.e. the first material.
+func (p *Provenance) SourceURI() (string, error) {
+	if len(p.materials) == 0 || p.materials[0].URI == "" {
+		return "", fmt.Errorf("%w: no source material", serrors.ErrorNotPresent)
+	}
+	return p.materials[0].URI, nil
+}
+
+// WorkflowInputs returns the inputs of the workflow that triggered the build.
+// It is only supported for provenance generated by the GitHub builders.
+func (p *Provenance) WorkflowInputs() (map[string]interface{}, error) {
+	if p.github == nil {
+		return nil, fmt.Errorf("%w: workflow inputs for build type '%s'", serrors.ErrorNotSupported, p.buildType)
+	}
+	inputs, err := p.github.GetWorkflowInputs()
+	if err != nil {
+		return nil, err
+	}
+	// Round-trip the inputs so that nested values are copied too.
+	b, err := json.Marshal(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+	}
+	var c map[string]interface{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%w: %s", serrors.ErrorInvalidDssePayload, err.Error())
+	}
+	return c, nil
+}
+
+// Branch returns the branch of the source the build was triggered on,
+// if any. It is only supported for provenance generated by the GitHub builders.
+func (p *Provenance) Branch() (string, error) {
+	if p.github == nil {
+		return "", fmt.Errorf("%w: branch for build type '%s'", serrors.ErrorNotSupported, p.buildType)
+	}
+	return p.github.GetBranch()
+}
+
+// Tag returns the tag of the source the build was triggered on, if any.
+// It is only supported for provenance generated by the GitHub builders.
+func (p *Provenance) Tag() (string, error) {
+	if p.github == nil {
+		return "", fmt.Errorf("%w: tag for build type '%s'", serrors.ErrorNotSupported, p.buildType)
+	}
+	return p.github.GetTag()
+}
+
+// PredicateV01 returns a copy of the SLSA v0.1 predicate.
+// It returns false if the predicate is of another type.
+func (p *Provenance) PredicateV01() (*slsa01.ProvenancePredicate, bool) {
+	return predicateAs[slsa01.ProvenancePredicate](p, PredicateSLSAProvenanceV01)
+}
+
+// PredicateV02 returns a copy of the SLSA v0.2 predicate.
+// It returns false if the predicate is of another type.
+func (p *Provenance) PredicateV02() (*slsa02.ProvenancePredicate, bool) {
+	return predicateAs[slsa02.ProvenancePredicate](p, PredicateSLSAProvenanceV02)
+}
+
+// PredicateV1 returns a copy of the SLSA v1.0 predicate.
+// It returns false if the predicate is of another type.
+func (p *Provenance) PredicateV1() (*slsa1.ProvenancePredicate, bool) {
+	return predicateAs[slsa1.ProvenancePredicate](p, PredicateSLSAProvenanceV1)
+}
+
+// predicateAs parses the predicate again, so that the caller gets a copy.
+func predicateAs[T any](p *Provenance, predicateType string) (*T, bool) {
+	if p.header.PredicateType != predicateType {
+		return nil, false
+	}
+	// The statement was already parsed successfully in New.
+	pred, err := unmarshalPredicate[T](p.statement)
+	if err != nil {
+		return nil, false
+	}
+	return pred, true
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
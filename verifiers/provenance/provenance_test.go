@@ -0,0 +1,241 @@
+package provenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
+)
+
+const (
+	digest = "8f3bd4a1d7e3b1c4e1b0d7c1b8a3f6e2d5c4b3a29180f7e6d5c4b3a291807f6e"
+	commit = "0123456789abcdef0123456789abcdef01234567"
+)
+
+// A statement generated by the generic generator.
+const statementV02 = `{
+	"_type": "https://in-toto.io/Statement/v0.1",
+	"subject": [{"name": "binary", "digest": {"sha256": "` + digest + `"}}],
+	"predicateType": "https://slsa.dev/provenance/v0.2",
+	"predicate": {
+		"builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.7.0"},
+		"buildType": "https://github.com/slsa-framework/slsa-github-generator/generic@v1",
+		"invocation": {
+			"configSource": {"uri": "git+https://github.com/org/repo@refs/heads/main", "entryPoint": ".github/workflows/release.yml"},
+			"environment": {
+				"github_event_name": "workflow_dispatch",
+				"github_ref": "refs/heads/main",
+				"github_ref_type": "branch",
+				"github_event_payload": {"inputs": {"release": "true"}}
+			}
+		},
+		"metadata": {
+			"buildInvocationID": "4351-1",
+			"buildStartedOn": "2023-06-01T10:00:00Z",
+			"buildFinishedOn": "2023-06-01T10:05:00Z"
+		},
+		"materials": [{"uri": "git+https://github.com/org/repo@refs/heads/main", "digest": {"sha1": "` + commit + `"}}]
+	}
+}`
+
+// A statement generated on GitLab CI.
+const statementV1 = `{
+	"_type": "https://in-toto.io/Statement/v1",
+	"subject": [{"name": "binary", "digest": {"sha256": "` + digest + `"}}],
+	"predicateType": "https://slsa.dev/provenance/v1",
+	"predicate": {
+		"buildDefinition": {
+			"buildType": "https://gitlab.com/gitlab-org/gitlab-runner/-/blob/v16.8.0/PROVENANCE.md",
+			"externalParameters": {},
+			"resolvedDependencies": [{"uri": "https://gitlab.com/group/project", "digest": {"sha1": "` + commit + `"}}]
+		},
+		"runDetails": {
+			"builder": {"id": "https://gitlab.com/group/project/-/runners/12"},
+			"metadata": {"invocationID": "42", "startedOn": "2023-06-01T10:00:00Z"}
+		}
+	}
+}`
+
+// A statement generated by Google Cloud Build.
+const statementV01 = `{
+	"_type": "https://in-toto.io/Statement/v0.1",
+	"subject": [{"name": "image", "digest": {"sha256": "` + digest + `"}}],
+	"predicateType": "https://slsa.dev/provenance/v0.1",
+	"predicate": {
+		"builder": {"id": "https://cloudbuild.googleapis.com/GoogleHostedWorker@v0.2"},
+		"recipe": {"type": "https://cloudbuild.googleapis.com/CloudBuildYaml@v0.1", "entryPoint": "cloudbuild.yaml"},
+		"metadata": {"buildStartedOn": "2023-06-01T10:00:00Z", "completeness": {}, "reproducible": false},
+		"materials": [{"uri": "https://github.com/org/repo", "digest": {"sha1": "` + commit + `"}}]
+	}
+}`
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	finished := time.Date(2023, 6, 1, 10, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		statement         string
+		predicateType     string
+		buildType         string
+		builderID         string
+		invocationID      string
+		startedOn         *time.Time
+		finishedOn        *time.Time
+		sourceURI         string
+		expectedInputs    map[string]interface{}
+		expectedInputsErr error
+		expected          error
+	}{
+		{
+			name:           "slsa v0.2 github",
+			statement:      statementV02,
+			predicateType:  PredicateSLSAProvenanceV02,
+			buildType:      "https://github.com/slsa-framework/slsa-github-generator/generic@v1",
+			builderID:      "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.7.0",
+			invocationID:   "4351-1",
+			startedOn:      &started,
+			finishedOn:     &finished,
+			sourceURI:      "git+https://github.com/org/repo@refs/heads/main",
+			expectedInputs: map[string]interface{}{"release": "true"},
+		},
+		{
+			name:              "slsa v1.0 gitlab",
+			statement:         statementV1,
+			predicateType:     PredicateSLSAProvenanceV1,
+			buildType:         "https://gitlab.com/gitlab-org/gitlab-runner/-/blob/v16.8.0/PROVENANCE.md",
+			builderID:         "https://gitlab.com/group/project/-/runners/12",
+			invocationID:      "42",
+			startedOn:         &started,
+			sourceURI:         "https://gitlab.com/group/project",
+			expectedInputsErr: serrors.ErrorNotSupported,
+		},
+		{
+			name:              "slsa v0.1 gcb",
+			statement:         statementV01,
+			predicateType:     PredicateSLSAProvenanceV01,
+			buildType:         "https://cloudbuild.googleapis.com/CloudBuildYaml@v0.1",
+			builderID:         "https://cloudbuild.googleapis.com/GoogleHostedWorker@v0.2",
+			startedOn:         &started,
+			sourceURI:         "https://github.com/org/repo",
+			expectedInputsErr: serrors.ErrorNotSupported,
+		},
+		{
+			name:      "invalid json",
+			statement: `{`,
+			expected:  serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name:      "invalid statement type",
+			statement: `{"_type": "https://in-toto.io/Statement/v2", "predicateType": "https://slsa.dev/provenance/v1"}`,
+			expected:  serrors.ErrorInvalidDssePayload,
+		},
+		{
+			name:      "unsupported predicate type",
+			statement: `{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://slsa.dev/verification_summary/v1"}`,
+			expected:  serrors.ErrorInvalidDssePayload,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := New([]byte(tt.statement))
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+
+			if p.PredicateType() != tt.predicateType {
+				t.Errorf("unexpected predicate type: %s", p.PredicateType())
+			}
+			if p.BuildType() != tt.buildType {
+				t.Errorf("unexpected build type: %s", p.BuildType())
+			}
+			if p.BuilderID() != tt.builderID {
+				t.Errorf("unexpected builder ID: %s", p.BuilderID())
+			}
+			if p.BuildInvocationID() != tt.invocationID {
+				t.Errorf("unexpected invocation ID: %s", p.BuildInvocationID())
+			}
+			if diff := cmp.Diff(tt.startedOn, p.BuildStartTime()); diff != "" {
+				t.Errorf("unexpected start time (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.finishedOn, p.BuildFinishTime()); diff != "" {
+				t.Errorf("unexpected finish time (-want +got): \n%s", diff)
+			}
+			wantSubjects := []intoto.Subject{{Name: p.Subjects()[0].Name, Digest: map[string]string{"sha256": digest}}}
+			if diff := cmp.Diff(wantSubjects, p.Subjects()); diff != "" {
+				t.Errorf("unexpected subjects (-want +got): \n%s", diff)
+			}
+			wantMaterials := []Material{{URI: tt.sourceURI, Digest: map[string]string{"sha1": commit}}}
+			if diff := cmp.Diff(wantMaterials, p.Materials()); diff != "" {
+				t.Errorf("unexpected materials (-want +got): \n%s", diff)
+			}
+			sourceURI, err := p.SourceURI()
+			if err != nil || sourceURI != tt.sourceURI {
+				t.Errorf("unexpected source URI: %s, %v", sourceURI, err)
+			}
+
+			inputs, err := p.WorkflowInputs()
+			if !cmp.Equal(err, tt.expectedInputsErr, cmpopts.EquateErrors()) {
+				t.Errorf(cmp.Diff(err, tt.expectedInputsErr, cmpopts.EquateErrors()))
+			}
+			if diff := cmp.Diff(tt.expectedInputs, inputs); diff != "" {
+				t.Errorf("unexpected inputs (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	p, err := FromVerificationResult(&utils.VerificationResult{Statement: []byte(statementV02)})
+	if err != nil {
+		t.Fatalf("FromVerificationResult: %v", err)
+	}
+
+	p.Subjects()[0].Digest["sha256"] = "modified"
+	p.Materials()[0].Digest["sha1"] = "modified"
+	*p.BuildStartTime() = time.Time{}
+	inputs, err := p.WorkflowInputs()
+	if err != nil {
+		t.Fatalf("WorkflowInputs: %v", err)
+	}
+	inputs["release"] = "false"
+	pred, ok := p.PredicateV02()
+	if !ok {
+		t.Fatalf("expected a v0.2 predicate")
+	}
+	pred.Builder.ID = "modified"
+
+	if p.Subjects()[0].Digest["sha256"] != digest {
+		t.Errorf("subjects were modified")
+	}
+	if p.Materials()[0].Digest["sha1"] != commit {
+		t.Errorf("materials were modified")
+	}
+	if p.BuildStartTime().IsZero() {
+		t.Errorf("start time was modified")
+	}
+	if inputs, _ := p.WorkflowInputs(); inputs["release"] != "true" {
+		t.Errorf("workflow inputs were modified")
+	}
+	if pred, _ := p.PredicateV02(); pred.Builder.ID == "modified" {
+		t.Errorf("predicate was modified")
+	}
+	if _, ok := p.PredicateV1(); ok {
+		t.Errorf("unexpected v1.0 predicate")
+	}
+}
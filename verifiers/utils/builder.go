@@ -41,8 +41,13 @@ func (b *TrustedBuilderID) MatchesLoose(builderID string, allowRef bool) error {
 	}
 
 	if name != b.name {
-		return fmt.Errorf("%w: expected name '%s', got '%s'", serrors.ErrorMismatchBuilderID,
-			b.name, name)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchBuilderID,
+			Check:    CheckBuilderID,
+			Field:    "builder ID name",
+			Expected: name,
+			Actual:   b.name,
+		}
 	}
 
 	if version != "" && version != b.version {
@@ -51,8 +56,13 @@ func (b *TrustedBuilderID) MatchesLoose(builderID string, allowRef bool) error {
 			"refs/tags/"+version == b.version {
 			return nil
 		}
-		return fmt.Errorf("%w: expected version '%s', got '%s'", serrors.ErrorMismatchBuilderID,
-			version, b.version)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchBuilderID,
+			Check:    CheckBuilderID,
+			Field:    "builder ID version",
+			Expected: version,
+			Actual:   b.version,
+		}
 	}
 
 	return nil
@@ -67,8 +77,13 @@ func (b *TrustedBuilderID) MatchesFull(builderID string, allowRef bool) error {
 	}
 
 	if name != b.name {
-		return fmt.Errorf("%w: expected name '%s', got '%s'", serrors.ErrorMismatchBuilderID,
-			b.name, name)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchBuilderID,
+			Check:    CheckBuilderID,
+			Field:    "builder ID name",
+			Expected: name,
+			Actual:   b.name,
+		}
 	}
 
 	if version != b.version {
@@ -77,8 +92,13 @@ func (b *TrustedBuilderID) MatchesFull(builderID string, allowRef bool) error {
 			"refs/tags/"+version == b.version {
 			return nil
 		}
-		return fmt.Errorf("%w: expected version '%s', got '%s'", serrors.ErrorMismatchBuilderID,
-			version, b.version)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchBuilderID,
+			Check:    CheckBuilderID,
+			Field:    "builder ID version",
+			Expected: version,
+			Actual:   b.version,
+		}
 	}
 
 	return nil
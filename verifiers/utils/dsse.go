@@ -1,10 +1,16 @@
 package utils
 
 import (
+	"context"
+	"crypto"
 	"encoding/base64"
 	"fmt"
 
 	dsselib "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	sigstoredsse "github.com/sigstore/sigstore/pkg/signature/dsse"
+
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
 )
 
@@ -43,3 +49,70 @@ func DecodeSignature(s string) ([]byte, error) {
 
 	return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidEncoding, errs)
 }
+
+// SignEnvelope signs the payload with the PEM-encoded private key
+// and returns the DSSE envelope. The key ID of the signature is
+// the SHA256 of the public key.
+func SignEnvelope(ctx context.Context, payloadType string, payload, privateKeyPEM []byte) (*dsselib.Envelope, error) {
+	privateKey, err := cryptoutils.UnmarshalPEMToPrivateKey(privateKeyPEM, cryptoutils.SkipPassword)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidKey, err)
+	}
+	signer, err := signature.LoadSigner(privateKey, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidKey, err)
+	}
+	publicKey, err := signer.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidKey, err)
+	}
+	keyID, err := dsselib.SHA256KeyID(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInvalidKey, err)
+	}
+
+	envSigner, err := dsselib.NewEnvelopeSigner(&sigstoredsse.SignerAdapter{
+		SignatureSigner: signer,
+		Pub:             publicKey,
+		PubKeyID:        keyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInternal, err)
+	}
+	env, err := envSigner.SignPayload(ctx, payloadType, payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrorInternal, err)
+	}
+	return env, nil
+}
+
+// VerifyEnvelope verifies that a DSSE envelope is signed with the public key,
+// and returns the verified payload and the key ID of the signature.
+func VerifyEnvelope(ctx context.Context, env *dsselib.Envelope, publicKey crypto.PublicKey) ([]byte, string, error) {
+	verifier, err := signature.LoadVerifier(publicKey, crypto.SHA256)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", serrors.ErrorInvalidKey, err)
+	}
+	keyID, err := dsselib.SHA256KeyID(publicKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", serrors.ErrorInvalidKey, err)
+	}
+
+	envVerifier, err := dsselib.NewEnvelopeVerifier(&sigstoredsse.VerifierAdapter{
+		SignatureVerifier: verifier,
+		Pub:               publicKey,
+		PubKeyID:          keyID,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", serrors.ErrorInternal, err)
+	}
+	if _, err := envVerifier.Verify(ctx, env); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", serrors.ErrorInvalidSignature, err)
+	}
+
+	payload, err := PayloadFromEnvelope(env)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, keyID, nil
+}
@@ -1,10 +1,15 @@
 package utils
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
 
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
 )
@@ -53,3 +58,113 @@ func Test_DecodeSignature(t *testing.T) {
 		})
 	}
 }
+
+func Test_SignEnvelope(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	privateKeyPEM, err := cryptoutils.MarshalPrivateKeyToPEM(privateKey)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKeyToPEM: %v", err)
+	}
+	publicKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(privateKey.Public())
+	if err != nil {
+		t.Fatalf("MarshalPublicKeyToPEM: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		key      []byte
+		expected error
+	}{
+		{
+			name: "valid key",
+			key:  privateKeyPEM,
+		},
+		{
+			name:     "public key",
+			key:      publicKeyPEM,
+			expected: serrors.ErrorInvalidKey,
+		},
+		{
+			name:     "invalid PEM",
+			key:      []byte("not a key"),
+			expected: serrors.ErrorInvalidKey,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env, err := SignEnvelope(context.Background(), "application/vnd.in-toto+json", []byte("payload"), tt.key)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if err != nil {
+				return
+			}
+
+			payload, _, err := VerifyEnvelope(context.Background(), env, privateKey.Public())
+			if err != nil {
+				t.Fatalf("VerifyEnvelope: %v", err)
+			}
+			if string(payload) != "payload" {
+				t.Errorf(cmp.Diff(string(payload), "payload"))
+			}
+		})
+	}
+}
+
+func Test_VerifyEnvelope(t *testing.T) {
+	t.Parallel()
+
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signingKeyPEM, err := cryptoutils.MarshalPrivateKeyToPEM(signingKey)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKeyToPEM: %v", err)
+	}
+	env, err := SignEnvelope(context.Background(), "application/vnd.in-toto+json", []byte("payload"), signingKeyPEM)
+	if err != nil {
+		t.Fatalf("SignEnvelope: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		key      *ecdsa.PrivateKey
+		expected error
+	}{
+		{
+			name: "signing key",
+			key:  signingKey,
+		},
+		{
+			name:     "other key",
+			key:      otherKey,
+			expected: serrors.ErrorInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := VerifyEnvelope(context.Background(), env, tt.key.Public())
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Errorf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package utils
+
+import "time"
+
+// Names of the checks recorded in a VerificationResult.
+const (
+	CheckSignature        = "signature"
+	CheckTransparencyLog  = "transparency-log"
+	CheckBuilderID        = "builder-id"
+	CheckSourceURI        = "source-uri"
+	CheckSubjectDigest    = "subject-digest"
+	CheckBranch           = "branch"
+	CheckTag              = "tag"
+	CheckVersionedTag     = "versioned-tag"
+	CheckWorkflowInputs   = "workflow-inputs"
+	CheckPackageName      = "package-name"
+	CheckPackageVersion   = "package-version"
+	CheckIntotoHeaders    = "intoto-headers"
+	CheckPublishSignature = "publish-signature"
+	CheckResourceURI      = "resource-uri"
+	CheckVerifierID       = "verifier-id"
+	CheckVerificationPass = "verification-result"
+	CheckVerifiedLevels   = "verified-levels"
+)
+
+// VerificationResult contains the information learned while
+// verifying provenance.
+type VerificationResult struct {
+	// Statement is the verified in-toto statement.
+	Statement []byte
+	// BuilderID is the verified builder ID.
+	BuilderID *TrustedBuilderID
+	// SourceURI is the source repository the artifact was built from.
+	SourceURI string
+	// SourceCommit is the commit the artifact was built from.
+	SourceCommit string
+	// WorkflowIdentity is the identity of the workflow that signed
+	// the provenance. It is nil if the provenance is not signed
+	// with a Fulcio certificate.
+	WorkflowIdentity *WorkflowIdentity
+	// TransparencyLogEntry is the transparency log entry the signature
+	// was verified against. It is nil if the signature is not logged.
+	TransparencyLogEntry *TransparencyLogEntry
+	// SigningKey identifies the key that signed the provenance when
+	// it is not signed with a Fulcio certificate, e.g. the GCB region key.
+	SigningKey string
+	// ProvenanceLine is the 1-based line of the attestation that verified,
+	// for provenance files in the JSON Lines format. It is 0 if the
+	// provenance is a single JSON document.
+	ProvenanceLine int
+	// Checks are the names of the checks that were performed.
+	Checks []string
+	// BuildLevel is the SLSA build level achieved by the builder.
+	BuildLevel int
+}
+
+// WorkflowIdentity is the identity of the workflow that signed the provenance,
+// as found in the Fulcio signing certificate.
+type WorkflowIdentity struct {
+	// Issuer is the OIDC issuer of the certificate.
+	Issuer string
+	// SourceRepository is the source repository.
+	SourceRepository string
+	// SourceSha1 is the commit SHA where the workflow was triggered.
+	SourceSha1 string
+	// SourceRef is the ref of the source.
+	SourceRef string
+	// SubjectWorkflowRef is the ref of the reusable or trigger workflow.
+	SubjectWorkflowRef string
+	// BuildTrigger is the event that triggered the workflow.
+	BuildTrigger string
+	// BuildConfigPath is the path to the trigger workflow.
+	BuildConfigPath string
+	// RunID is the ID of the workflow run.
+	RunID string
+	// Hosted is the hosted status of the runner, e.g. "github-hosted".
+	Hosted string
+}
+
+// TransparencyLogEntry is a verified transparency log entry.
+type TransparencyLogEntry struct {
+	// URL is the address of the entry on the transparency log.
+	// It may be empty if the entry was not fetched from the log.
+	URL string
+	// LogID is the ID of the transparency log.
+	LogID string
+	// LogIndex is the index of the entry in the log.
+	LogIndex int64
+	// IntegratedTime is the time the entry was added to the log.
+	IntegratedTime time.Time
+}
+
+// AddChecks records that checks were performed.
+func (r *VerificationResult) AddChecks(names ...string) {
+	r.Checks = append(r.Checks, names...)
+}
+
+// ArtifactResult is the result of verifying one artifact of a batch.
+type ArtifactResult struct {
+	// ArtifactHash is the hash of the artifact.
+	ArtifactHash string
+	// Result is set if the artifact passed verification.
+	Result *VerificationResult
+	// Err is set if the artifact failed verification.
+	Err error
+}
@@ -22,8 +22,13 @@ func VerifyVersionedTag(provenanceTag, expectedTag string) error {
 	expectedMajor := semver.Major(expectedTag)
 	major := semver.Major(semTag)
 	if major != expectedMajor {
-		return fmt.Errorf("%w: major version expected '%s', got '%s'",
-			serrors.ErrorMismatchVersionedTag, expectedMajor, major)
+		return &serrors.VerificationError{
+			Err:      serrors.ErrorMismatchVersionedTag,
+			Check:    CheckVersionedTag,
+			Field:    "tag major version",
+			Expected: expectedMajor,
+			Actual:   major,
+		}
 	}
 
 	expectedMinor, err := minorVersion(expectedTag)
@@ -35,8 +40,13 @@ func VerifyVersionedTag(provenanceTag, expectedTag string) error {
 		}
 
 		if minor != expectedMinor {
-			return fmt.Errorf("%w: minor version expected '%s', got '%s'",
-				serrors.ErrorMismatchVersionedTag, expectedMinor, minor)
+			return &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchVersionedTag,
+				Check:    CheckVersionedTag,
+				Field:    "tag minor version",
+				Expected: expectedMinor,
+				Actual:   minor,
+			}
 		}
 	}
 
@@ -49,8 +59,13 @@ func VerifyVersionedTag(provenanceTag, expectedTag string) error {
 		}
 
 		if patch != expectedPatch {
-			return fmt.Errorf("%w: patch version expected '%s', got '%s'",
-				serrors.ErrorMismatchVersionedTag, expectedPatch, patch)
+			return &serrors.VerificationError{
+				Err:      serrors.ErrorMismatchVersionedTag,
+				Check:    CheckVersionedTag,
+				Field:    "tag patch version",
+				Expected: expectedPatch,
+				Actual:   patch,
+			}
 		}
 	}
 
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+)
+
+const (
+	// StatementInTotoV1 is the type of in-toto v1 statements.
+	StatementInTotoV1 = "https://in-toto.io/Statement/v1"
+	// PredicateVSA is the predicate type of SLSA verification summary attestations.
+	PredicateVSA = "https://slsa.dev/verification_summary/v1"
+	// VerifierID is the ID of slsa-verifier in the VSAs it emits.
+	VerifierID = "https://github.com/slsa-framework/slsa-verifier"
+)
+
+// Verification results recorded in a VSA.
+const (
+	VerificationPassed = "PASSED"
+	VerificationFailed = "FAILED"
+)
+
+const buildLevelPrefix = "SLSA_BUILD_LEVEL_"
+
+// BuildLevel returns the name of a SLSA build level, e.g. SLSA_BUILD_LEVEL_3.
+func BuildLevel(level int) string {
+	return fmt.Sprintf("%s%d", buildLevelPrefix, level)
+}
+
+// ParseBuildLevel returns the level of a SLSA build level name.
+// It returns false if the name is not a build level, e.g. for
+// levels of other SLSA tracks.
+func ParseBuildLevel(name string) (int, bool) {
+	if !strings.HasPrefix(name, buildLevelPrefix) {
+		return 0, false
+	}
+	level, err := strconv.Atoi(strings.TrimPrefix(name, buildLevelPrefix))
+	if err != nil || level < 0 {
+		return 0, false
+	}
+	return level, true
+}
+
+// VSA is an in-toto statement with a SLSA verification summary predicate.
+type VSA struct {
+	intoto.StatementHeader
+	Predicate VSAPredicate `json:"predicate"`
+}
+
+// VSAPredicate is the SLSA verification summary predicate.
+// See https://slsa.dev/spec/v1.0/verification_summary.
+type VSAPredicate struct {
+	Verifier           VSAVerifier                `json:"verifier"`
+	TimeVerified       time.Time                  `json:"timeVerified"`
+	ResourceURI        string                     `json:"resourceUri"`
+	Policy             slsa1.ResourceDescriptor   `json:"policy"`
+	InputAttestations  []slsa1.ResourceDescriptor `json:"inputAttestations,omitempty"`
+	VerificationResult string                     `json:"verificationResult"`
+	VerifiedLevels     []string                   `json:"verifiedLevels"`
+	DependencyLevels   map[string]uint64          `json:"dependencyLevels,omitempty"`
+	SlsaVersion        string                     `json:"slsaVersion,omitempty"`
+}
+
+// VSAVerifier identifies the verifier that emitted a VSA.
+type VSAVerifier struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+// VSAOpts are the options to create a VSA.
+type VSAOpts struct {
+	// ResourceURI is the URI of the verified artifact.
+	ResourceURI string
+	// Digest is the hex-encoded sha256 digest of the verified artifact.
+	Digest string
+	// Policy describes the expectations the artifact was verified against.
+	Policy slsa1.ResourceDescriptor
+	// VerifierVersion is the version of slsa-verifier.
+	VerifierVersion string
+	// TimeVerified is the time of the verification.
+	TimeVerified time.Time
+}
+
+// NewVSA returns a VSA summarizing the successful verification
+// of an artifact.
+func NewVSA(result *VerificationResult, opts *VSAOpts) (*VSA, error) {
+	if result == nil || opts == nil {
+		return nil, fmt.Errorf("%w: empty verification result", serrors.ErrorInternal)
+	}
+	if opts.ResourceURI == "" || opts.Digest == "" {
+		return nil, fmt.Errorf("%w: empty resource URI or digest", serrors.ErrorInternal)
+	}
+
+	// The verified provenance is the input attestation. Its URI is
+	// the transparency log entry it was verified against, if any.
+	input := slsa1.ResourceDescriptor{
+		Digest: map[string]string{"sha256": SHA256Hex(result.Statement)},
+	}
+	if result.TransparencyLogEntry != nil {
+		input.URI = result.TransparencyLogEntry.URL
+	}
+
+	var version map[string]string
+	if opts.VerifierVersion != "" {
+		version = map[string]string{"slsa-verifier": opts.VerifierVersion}
+	}
+
+	return &VSA{
+		StatementHeader: intoto.StatementHeader{
+			Type:          StatementInTotoV1,
+			PredicateType: PredicateVSA,
+			Subject: []intoto.Subject{
+				{
+					Name:   opts.ResourceURI,
+					Digest: map[string]string{"sha256": opts.Digest},
+				},
+			},
+		},
+		Predicate: VSAPredicate{
+			Verifier: VSAVerifier{
+				ID:      VerifierID,
+				Version: version,
+			},
+			TimeVerified:       opts.TimeVerified.UTC(),
+			ResourceURI:        opts.ResourceURI,
+			Policy:             opts.Policy,
+			InputAttestations:  []slsa1.ResourceDescriptor{input},
+			VerificationResult: VerificationPassed,
+			VerifiedLevels:     []string{BuildLevel(result.BuildLevel)},
+			SlsaVersion:        "1.0",
+		},
+	}, nil
+}
+
+// SHA256Hex returns the hex-encoded sha256 digest of b.
+func SHA256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+
+	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+)
+
+func Test_NewVSA(t *testing.T) {
+	t.Parallel()
+
+	verified := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := slsa1.ResourceDescriptor{
+		URI:    "policy.yml",
+		Digest: map[string]string{"sha256": "abcd"},
+	}
+	// sha256 of "statement".
+	statementDigest := "b111c6e1d318f203063e5c16bab43c108326af0aa2f7b65760c95547a43dbe52"
+
+	tests := []struct {
+		name     string
+		result   *VerificationResult
+		opts     *VSAOpts
+		expected error
+		want     *VSA
+	}{
+		{
+			name: "artifact with tlog entry",
+			result: &VerificationResult{
+				Statement: []byte("statement"),
+				TransparencyLogEntry: &TransparencyLogEntry{
+					URL: "https://rekor.sigstore.dev/api/v1/log/entries?logIndex=1",
+				},
+				BuildLevel: 3,
+			},
+			opts: &VSAOpts{
+				ResourceURI:     "binary-linux-amd64",
+				Digest:          "1234",
+				Policy:          policy,
+				VerifierVersion: "v2.3.0",
+				TimeVerified:    verified,
+			},
+			want: &VSA{
+				StatementHeader: intoto.StatementHeader{
+					Type:          StatementInTotoV1,
+					PredicateType: PredicateVSA,
+					Subject: []intoto.Subject{
+						{Name: "binary-linux-amd64", Digest: map[string]string{"sha256": "1234"}},
+					},
+				},
+				Predicate: VSAPredicate{
+					Verifier: VSAVerifier{
+						ID:      VerifierID,
+						Version: map[string]string{"slsa-verifier": "v2.3.0"},
+					},
+					TimeVerified: verified,
+					ResourceURI:  "binary-linux-amd64",
+					Policy:       policy,
+					InputAttestations: []slsa1.ResourceDescriptor{
+						{
+							URI:    "https://rekor.sigstore.dev/api/v1/log/entries?logIndex=1",
+							Digest: map[string]string{"sha256": statementDigest},
+						},
+					},
+					VerificationResult: VerificationPassed,
+					VerifiedLevels:     []string{"SLSA_BUILD_LEVEL_3"},
+					SlsaVersion:        "1.0",
+				},
+			},
+		},
+		{
+			name:   "no version nor tlog entry",
+			result: &VerificationResult{Statement: []byte("statement"), BuildLevel: 2},
+			opts: &VSAOpts{
+				ResourceURI:  "ghcr.io/org/repo@sha256:1234",
+				Digest:       "1234",
+				Policy:       policy,
+				TimeVerified: verified,
+			},
+			want: &VSA{
+				StatementHeader: intoto.StatementHeader{
+					Type:          StatementInTotoV1,
+					PredicateType: PredicateVSA,
+					Subject: []intoto.Subject{
+						{Name: "ghcr.io/org/repo@sha256:1234", Digest: map[string]string{"sha256": "1234"}},
+					},
+				},
+				Predicate: VSAPredicate{
+					Verifier:     VSAVerifier{ID: VerifierID},
+					TimeVerified: verified,
+					ResourceURI:  "ghcr.io/org/repo@sha256:1234",
+					Policy:       policy,
+					InputAttestations: []slsa1.ResourceDescriptor{
+						{Digest: map[string]string{"sha256": statementDigest}},
+					},
+					VerificationResult: VerificationPassed,
+					VerifiedLevels:     []string{"SLSA_BUILD_LEVEL_2"},
+					SlsaVersion:        "1.0",
+				},
+			},
+		},
+		{
+			name:     "empty digest",
+			result:   &VerificationResult{},
+			opts:     &VSAOpts{ResourceURI: "binary-linux-amd64"},
+			expected: serrors.ErrorInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewVSA(tt.result, tt.opts)
+			if !cmp.Equal(err, tt.expected, cmpopts.EquateErrors()) {
+				t.Fatalf(cmp.Diff(err, tt.expected, cmpopts.EquateErrors()))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected VSA (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
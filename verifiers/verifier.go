@@ -2,13 +2,17 @@ package verifiers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	serrors "github.com/slsa-framework/slsa-verifier/v2/errors"
+	"github.com/slsa-framework/slsa-verifier/v2/events"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/register"
 	_ "github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gcb"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gha"
+	_ "github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/gitlab"
+	_ "github.com/slsa-framework/slsa-verifier/v2/verifiers/internal/vsa"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers/utils"
 )
 
@@ -35,43 +39,171 @@ func getVerifier(builderOpts *options.BuilderOpts) (register.SLSAVerifier, error
 	return verifier, nil
 }
 
+// VerifierName returns the name of the verifier that verifies provenance
+// generated by the builder in builderOpts, e.g. GHA, GCB or GitLab.
+func VerifierName(builderOpts *options.BuilderOpts) (string, error) {
+	verifier, err := getVerifier(builderOpts)
+	if err != nil {
+		return "", err
+	}
+	for name, v := range register.SLSAVerifiers {
+		if v == verifier {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unregistered verifier", serrors.ErrorInternal)
+}
+
+// LoadTrustedRoot loads the Sigstore verification material (Fulcio roots and
+// intermediates, Rekor and CT log keys) from a trusted_root.json file.
+// Subsequent verifications use it instead of fetching the material from TUF,
+// which allows verifying Sigstore bundles and images without network access.
+// The trusted root is shared by the whole process: callers that need
+// different roots set options.VerifierOpts.TrustedRoot for each verification.
+func LoadTrustedRoot(path string) error {
+	trustedRoot, err := gha.TrustedRootFromFile(path)
+	if err != nil {
+		return err
+	}
+	gha.SetTrustedRoot(trustedRoot)
+	return nil
+}
+
+// InitTrustedRoot fetches the Sigstore verification material from TUF,
+// unless it is already loaded. Verifications otherwise fetch it on first use.
+func InitTrustedRoot(ctx context.Context) error {
+	_, err := gha.TrustedRootSingleton(ctx)
+	return err
+}
+
+// TrustedRootLoaded returns true once the Sigstore verification material
+// has been loaded by InitTrustedRoot, LoadTrustedRoot or a verification.
+func TrustedRootLoaded() bool {
+	return gha.TrustedRootLoaded()
+}
+
+// WithSignatureCache returns a context in which the verifications of
+// identical GitHub Actions provenance verify its signature and transparency
+// log entry only once, e.g. to verify a batch of artifacts with the same
+// provenance and different expectations. Only the verifications with the
+// same verifier options, and the same artifact for provenance that is
+// searched in Rekor by artifact digest, are shared. Failed verifications
+// are not shared.
+func WithSignatureCache(ctx context.Context) context.Context {
+	return gha.WithSignatureCache(ctx)
+}
+
 func VerifyImage(ctx context.Context, artifactImage string,
 	provenance []byte,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
 	verifier, err := getVerifier(builderOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return verifier.VerifyImage(ctx, provenance, artifactImage, provenanceOpts, builderOpts)
+	result, err := verifier.VerifyImage(ctx, provenance, artifactImage, provenanceOpts, builderOpts, verifierOpts)
+	emitChecks(ctx, result, err)
+	return result, err
 }
 
 func VerifyArtifact(ctx context.Context,
 	provenance []byte, artifactHash string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
 	verifier, err := getVerifier(builderOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return verifier.VerifyArtifact(ctx, provenance, artifactHash,
-		provenanceOpts, builderOpts)
+	result, err := verifier.VerifyArtifact(ctx, provenance, artifactHash,
+		provenanceOpts, builderOpts, verifierOpts)
+	emitChecks(ctx, result, err)
+	return result, err
+}
+
+// VerifyArtifacts verifies a provenance for several artifacts. The signature
+// is verified once, and the subject digest and other properties of the
+// provenance are verified for each artifact. It returns one result per
+// artifact hash, in the same order. The error is set if the provenance
+// could not be verified for any artifact.
+func VerifyArtifacts(ctx context.Context,
+	provenance []byte, artifactHashes []string,
+	provenanceOpts *options.ProvenanceOpts,
+	builderOpts *options.BuilderOpts,
+	verifierOpts *options.VerifierOpts,
+) ([]utils.ArtifactResult, error) {
+	verifier, err := getVerifier(builderOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := verifier.VerifyArtifacts(ctx, provenance, artifactHashes,
+		provenanceOpts, builderOpts, verifierOpts)
+	if err != nil {
+		emitChecks(ctx, nil, err)
+		return results, err
+	}
+	for _, r := range results {
+		emitChecks(ctx, r.Result, r.Err)
+	}
+	return results, nil
 }
 
 func VerifyNpmPackage(ctx context.Context,
 	attestations []byte, tarballHash string,
 	provenanceOpts *options.ProvenanceOpts,
 	builderOpts *options.BuilderOpts,
-) ([]byte, *utils.TrustedBuilderID, error) {
+	verifierOpts *options.VerifierOpts,
+) (*utils.VerificationResult, error) {
 	verifier, err := getVerifier(builderOpts)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return verifier.VerifyNpmPackage(ctx, attestations, tarballHash,
-		provenanceOpts, builderOpts)
+	result, err := verifier.VerifyNpmPackage(ctx, attestations, tarballHash,
+		provenanceOpts, builderOpts, verifierOpts)
+	emitChecks(ctx, result, err)
+	return result, err
+}
+
+// VerifyVSA verifies a SLSA verification summary attestation (VSA)
+// for an artifact. The VSA must be signed with the verifier key
+// in vsaOpts.
+func VerifyVSA(ctx context.Context,
+	attestation []byte,
+	vsaOpts *options.VSAOpts,
+) (*utils.VerificationResult, error) {
+	for _, v := range register.VSAVerifiers {
+		if v.IsAuthoritativeFor(utils.PredicateVSA) {
+			result, err := v.VerifyVSA(ctx, attestation, vsaOpts)
+			emitChecks(ctx, result, err)
+			return result, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", serrors.ErrorVerifierNotSupported, utils.PredicateVSA)
+}
+
+// emitChecks emits the outcome of a verification: an events.CheckPassed
+// event per check of a successful verification, or an events.CheckFailed event.
+func emitChecks(ctx context.Context, result *utils.VerificationResult, err error) {
+	if err != nil {
+		var verr *serrors.VerificationError
+		if errors.As(err, &verr) {
+			events.Emit(ctx, events.CheckFailed{Check: verr.Check, Err: err})
+			return
+		}
+		events.Emit(ctx, events.CheckFailed{Err: err})
+		return
+	}
+	if result == nil {
+		return
+	}
+	for _, c := range result.Checks {
+		events.Emit(ctx, events.CheckPassed{Check: c})
+	}
 }